@@ -6,6 +6,7 @@ export default {
 	fetch: "",
 	pagination: "",
 	"order-by": "",
+	aggregate: "",
 	create: "",
 	update: "",
 	delete: "",
@@ -14,6 +15,8 @@ export default {
 	raw: "",
 	transactions: "",
 	composite: "",
+	"composite-types": "",
+	views: "",
 	fields: "",
 	limitations: "",
 };
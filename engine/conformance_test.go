@@ -0,0 +1,199 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/steebchen/prisma-client-go/engine/protocol"
+	"github.com/steebchen/prisma-client-go/runtime/types"
+)
+
+// conformanceEngines creates all engines which talk to a query engine over http, pointed at the given stand-in server
+var conformanceEngines = map[string]func(server *httptest.Server) Engine{
+	"query engine": func(server *httptest.Server) Engine {
+		return &QueryEngine{
+			http:      server.Client(),
+			httpURL:   server.URL,
+			connected: true,
+		}
+	},
+	"data proxy": func(server *httptest.Server) Engine {
+		return &DataProxyEngine{
+			http: server.Client(),
+			url:  server.URL,
+		}
+	},
+}
+
+// TestEngineConformance makes sure that all engines return the same results and errors for the same responses
+func TestEngineConformance(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		batch  bool
+		check  func(t *testing.T, err error, result json.RawMessage)
+	}{{
+		name: "result",
+		body: `{"data":{"result":{"id":"a"}}}`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"id":"a"}`, string(result))
+		},
+	}, {
+		name: "unique constraint",
+		body: `{"errors":[{"error":"unique","user_facing_error":{"is_panic":false,"message":"unique","error_code":"P2002","meta":{"target":["email"]}}}]}`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			info, ok := types.CheckUniqueConstraint[string](err)
+			assert.True(t, ok)
+			assert.Equal(t, []string{"email"}, info.Fields)
+		},
+	}, {
+		name: "typed error",
+		body: `{"errors":[{"error":"fk","user_facing_error":{"message":"fk","error_code":"P2003","meta":{"field_name":"Post_authorId_fkey (index)"}}}]}`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			var fk *types.ErrForeignKeyConstraint
+			assert.ErrorAs(t, err, &fk)
+			assert.Equal(t, "Post_authorId_fkey (index)", fk.Field)
+		},
+	}, {
+		name: "internal not found",
+		body: `{"errors":[{"error":` + mustJSON(internalUpdateNotFoundMessage) + `}]}`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			assert.ErrorIs(t, err, types.ErrNotFound)
+		},
+	}, {
+		name: "internal error",
+		body: `{"errors":[{"error":"something\nfailed"}]}`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			assert.EqualError(t, err, "internal error: something failed")
+		},
+	}, {
+		name:   "http error with query errors",
+		status: http.StatusInternalServerError,
+		body:   `{"errors":[{"error":"unreachable","user_facing_error":{"message":"unreachable","error_code":"P1001","meta":{"database_host":"db","database_port":"5432"}}}]}`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			var conn *types.ErrConnection
+			assert.ErrorAs(t, err, &conn)
+			assert.Equal(t, "db", conn.Host)
+		},
+	}, {
+		name:   "http error with user facing error",
+		status: http.StatusBadRequest,
+		body:   `{"is_panic":false,"message":"transaction closed","error_code":"P2028","meta":{"error":"closed"}}`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			var ufe *protocol.UserFacingError
+			assert.ErrorAs(t, err, &ufe)
+			assert.Equal(t, "P2028", ufe.ErrorCode)
+			assert.Equal(t, "closed", ufe.Meta.Values["error"])
+		},
+	}, {
+		name:   "http error with data proxy error",
+		status: http.StatusServiceUnavailable,
+		body:   `{"EngineNotStarted":{"reason":{"KnownEngineStartupError":{"msg":"unreachable","error_code":"P1001"}}}}`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			var conn *types.ErrConnection
+			assert.ErrorAs(t, err, &conn)
+		},
+	}, {
+		name:   "http error with unknown body",
+		status: http.StatusInternalServerError,
+		body:   `boom`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			assert.ErrorContains(t, err, "http status code 500 with response boom")
+		},
+	}, {
+		name:  "batch",
+		batch: true,
+		body:  `{"batchResult":[{"data":{"result":{"id":"a"}}},{"errors":[{"error":"unique","user_facing_error":{"message":"unique","error_code":"P2002"}}]}]}`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			assert.NoError(t, err)
+			var response protocol.GQLBatchResponse
+			assert.NoError(t, json.Unmarshal(result, &response))
+			assert.Equal(t, "P2002", response.Result[1].Errors[0].UserFacingError.ErrorCode)
+		},
+	}, {
+		name:  "transaction error",
+		batch: true,
+		body:  `{"errors":[{"error":"unique","user_facing_error":{"message":"unique","error_code":"P2002","batch_request_idx":1}}]}`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			assert.NoError(t, err)
+			var response protocol.GQLBatchResponse
+			assert.NoError(t, json.Unmarshal(result, &response))
+			assert.Equal(t, "P2002", response.Errors[0].UserFacingError.ErrorCode)
+			assert.Equal(t, 1, *response.Errors[0].UserFacingError.BatchRequestIdx)
+		},
+	}, {
+		name:   "batch http error",
+		batch:  true,
+		status: http.StatusInternalServerError,
+		body:   `{"errors":[{"error":"conflict","user_facing_error":{"message":"conflict","error_code":"P2034"}}]}`,
+		check: func(t *testing.T, err error, result json.RawMessage) {
+			var conflict *types.ErrTransactionConflict
+			assert.ErrorAs(t, err, &conflict)
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			var messages []string
+			for name, create := range conformanceEngines {
+				t.Run(name, func(t *testing.T) {
+					e := create(server)
+					ctx := context.Background()
+
+					var result json.RawMessage
+					var err error
+					if tt.batch {
+						var response protocol.GQLBatchResponse
+						err = e.Batch(ctx, protocol.GQLBatchRequest{}, &response)
+						if err == nil {
+							result, err = json.Marshal(response)
+						}
+					} else {
+						err = e.Do(ctx, protocol.GQLRequest{}, &result)
+					}
+					tt.check(t, err, result)
+
+					if err != nil {
+						messages = append(messages, err.Error())
+					}
+				})
+			}
+
+			// errors must not only match the same types, but also read the same
+			for _, message := range messages {
+				assert.Equal(t, messages[0], message)
+			}
+		})
+	}
+}
+
+func mustJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+func TestHTTPErrorFallback(t *testing.T) {
+	err := httpError(http.StatusBadGateway, []byte(`{"unexpected":true}`))
+	assert.EqualError(t, err, `http status code 502 with response {"unexpected":true}`)
+
+	var ufe *protocol.UserFacingError
+	assert.False(t, errors.As(err, &ufe))
+}
@@ -3,13 +3,16 @@ package engine
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/steebchen/prisma-client-go/engine/protocol"
 	"github.com/steebchen/prisma-client-go/logger"
+	"github.com/steebchen/prisma-client-go/runtime/types"
 )
 
 var errNotFound = fmt.Errorf("not found; re-upload schema")
@@ -52,7 +55,7 @@ func request(ctx context.Context, client *http.Client, method string, url string
 	}
 
 	if rawResponse.StatusCode != http.StatusOK && rawResponse.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("http status code %d with response %s", rawResponse.StatusCode, responseBody)
+		return nil, httpError(rawResponse.StatusCode, responseBody)
 	}
 
 	if logger.Enabled {
@@ -71,3 +74,46 @@ func request(ctx context.Context, client *http.Client, method string, url string
 
 	return responseBody, nil
 }
+
+// proxyError is the error body of the data proxy when the query engine could not be started
+type proxyError struct {
+	EngineNotStarted *struct {
+		Reason struct {
+			KnownEngineStartupError *struct {
+				Message   string `json:"msg"`
+				ErrorCode string `json:"error_code"`
+			} `json:"KnownEngineStartupError"`
+		} `json:"reason"`
+	} `json:"EngineNotStarted"`
+}
+
+// httpError returns the error of a response with an error status code. The body may hold a query response with
+// errors, a user facing error or a data proxy error, which are decoded so that their error codes are preserved.
+func httpError(status int, body []byte) error {
+	var response protocol.GQLResponse
+	if err := json.Unmarshal(body, &response); err == nil && len(response.Errors) > 0 {
+		return fmt.Errorf("http status code %d: %w", status, queryError(response.Errors[0]))
+	}
+
+	var gqlError protocol.GQLError
+	if err := json.Unmarshal(body, &gqlError); err == nil && gqlError.UserFacingError != nil {
+		return fmt.Errorf("http status code %d: %w", status, queryError(gqlError))
+	}
+
+	var userFacingError protocol.UserFacingError
+	if err := json.Unmarshal(body, &userFacingError); err == nil && userFacingError.ErrorCode != "" {
+		return fmt.Errorf("http status code %d: user facing error: %w", status, types.FromUserFacingError(&userFacingError))
+	}
+
+	var proxy proxyError
+	if err := json.Unmarshal(body, &proxy); err == nil && proxy.EngineNotStarted != nil {
+		if known := proxy.EngineNotStarted.Reason.KnownEngineStartupError; known != nil {
+			return fmt.Errorf("http status code %d: user facing error: %w", status, types.FromUserFacingError(&protocol.UserFacingError{
+				Message:   known.Message,
+				ErrorCode: known.ErrorCode,
+			}))
+		}
+	}
+
+	return fmt.Errorf("http status code %d with response %s", status, body)
+}
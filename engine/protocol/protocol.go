@@ -38,6 +38,9 @@ type UserFacingError struct {
 	Message   string `json:"message"`
 	Meta      Meta   `json:"meta"`
 	ErrorCode string `json:"error_code"`
+
+	// BatchRequestIdx is the index of the failed query of a transactional batch
+	BatchRequestIdx *int `json:"batch_request_idx,omitempty"`
 }
 
 func (e *UserFacingError) Error() string {
@@ -46,6 +49,28 @@ func (e *UserFacingError) Error() string {
 
 type Meta struct {
 	Target interface{} `json:"target"` // can be of type []string or string
+
+	// Values holds all meta fields of the error, which depend on the error code
+	Values map[string]interface{} `json:"-"`
+}
+
+func (m *Meta) UnmarshalJSON(data []byte) error {
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	m.Target = values["target"]
+	m.Values = values
+	return nil
+}
+
+func (m Meta) MarshalJSON() ([]byte, error) {
+	if m.Values != nil {
+		return json.Marshal(m.Values)
+	}
+	return json.Marshal(map[string]interface{}{
+		"target": m.Target,
+	})
 }
 
 // GQLError is a GraphQL Message
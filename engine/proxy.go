@@ -13,9 +13,7 @@ import (
 	"time"
 
 	"github.com/steebchen/prisma-client-go/binaries"
-	"github.com/steebchen/prisma-client-go/engine/protocol"
 	"github.com/steebchen/prisma-client-go/logger"
-	"github.com/steebchen/prisma-client-go/runtime/types"
 )
 
 func NewDataProxyEngine(schema, connectionURL string) *DataProxyEngine {
@@ -109,22 +107,8 @@ func (e *DataProxyEngine) Do(ctx context.Context, payload interface{}, into inte
 
 	startParse := time.Now()
 
-	var response protocol.GQLResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return fmt.Errorf("json gql resopnse unmarshal: %w", err)
-	}
-
-	if len(response.Errors) > 0 {
-		first := response.Errors[0]
-		if first.RawMessage() == internalUpdateNotFoundMessage ||
-			first.RawMessage() == internalDeleteNotFoundMessage {
-			return types.ErrNotFound
-		}
-		return fmt.Errorf("pql error: %s", first.RawMessage())
-	}
-
-	if err := json.Unmarshal(response.Data.Result, into); err != nil {
-		return fmt.Errorf("json data result unmarshal: %w", err)
+	if err := decodeResponse(body, into); err != nil {
+		return err
 	}
 
 	logger.Debug.Printf("[timing] request unmarshal took %s", time.Since(startParse))
@@ -143,11 +127,7 @@ func (e *DataProxyEngine) Batch(ctx context.Context, payload interface{}, into i
 		return fmt.Errorf("request failed: %w", err)
 	}
 
-	if err := json.Unmarshal(body, &into); err != nil {
-		return fmt.Errorf("json body unmarshal: %w", err)
-	}
-
-	return nil
+	return decodeBatch(body, into)
 }
 
 func (e *DataProxyEngine) Name() string {
@@ -29,32 +29,8 @@ func (e *QueryEngine) Do(ctx context.Context, payload interface{}, v interface{}
 
 	startParse := time.Now()
 
-	var response protocol.GQLResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return fmt.Errorf("json gql response unmarshal: %w", err)
-	}
-
-	if len(response.Errors) > 0 {
-		e := response.Errors[0]
-		if e.RawMessage() == internalUpdateNotFoundMessage ||
-			e.RawMessage() == internalDeleteNotFoundMessage {
-			return types.ErrNotFound
-		}
-
-		if e.UserFacingError != nil {
-			return fmt.Errorf("user facing error: %w", e.UserFacingError)
-		}
-
-		return fmt.Errorf("internal error: %s", e.RawMessage())
-	}
-
-	response.Data.Result, err = TransformResponse(response.Data.Result)
-	if err != nil {
-		return fmt.Errorf("transform response: %w", err)
-	}
-
-	if err := json.Unmarshal(response.Data.Result, v); err != nil {
-		return fmt.Errorf("json data result unmarshal: %w", err)
+	if err := decodeResponse(body, v); err != nil {
+		return err
 	}
 
 	logger.Debug.Printf("[timing] request unmarshaling took %s", time.Since(startParse))
@@ -69,16 +45,7 @@ func (e *QueryEngine) Batch(ctx context.Context, payload interface{}, v interfac
 		return fmt.Errorf("request failed: %w", err)
 	}
 
-	body, err = TransformResponse(body)
-	if err != nil {
-		return fmt.Errorf("transform response: %w", err)
-	}
-
-	if err := json.Unmarshal(body, &v); err != nil {
-		return fmt.Errorf("json body unmarshal: %w", err)
-	}
-
-	return nil
+	return decodeBatch(body, v)
 }
 
 func (e *QueryEngine) Request(ctx context.Context, method string, path string, payload interface{}, requiresConnection bool) ([]byte, error) {
@@ -102,5 +69,65 @@ func (e *QueryEngine) Request(ctx context.Context, method string, path string, p
 
 	return request(ctx, e.http, method, e.httpURL+path, requestBody, func(req *http.Request) {
 		req.Header.Set("content-type", "application/json")
+		if id, ok := TransactionID(ctx); ok {
+			req.Header.Set("X-transaction-id", id)
+		}
 	})
 }
+
+// decodeResponse decodes the result of a query response into v, or returns the first error of the response.
+// It is shared by all engines so that results and errors behave the same.
+func decodeResponse(body []byte, v interface{}) error {
+	var response protocol.GQLResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("json gql response unmarshal: %w", err)
+	}
+
+	if len(response.Errors) > 0 {
+		return queryError(response.Errors[0])
+	}
+
+	if _, ok := v.(*RawExtendedJSON); !ok {
+		var err error
+		response.Data.Result, err = TransformResponse(response.Data.Result)
+		if err != nil {
+			return fmt.Errorf("transform response: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal(response.Data.Result, v); err != nil {
+		return fmt.Errorf("json data result unmarshal: %w", err)
+	}
+
+	return nil
+}
+
+// decodeBatch decodes a batch response into v. Errors of the batch and of its queries are part of the response and
+// are handled by the caller.
+func decodeBatch(body []byte, v interface{}) error {
+	body, err := TransformResponse(body)
+	if err != nil {
+		return fmt.Errorf("transform response: %w", err)
+	}
+
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Errorf("json body unmarshal: %w", err)
+	}
+
+	return nil
+}
+
+// queryError returns the error of a query. Records which were not found return types.ErrNotFound, and user facing
+// errors are returned as typed errors, see types.FromUserFacingError.
+func queryError(e protocol.GQLError) error {
+	if e.RawMessage() == internalUpdateNotFoundMessage ||
+		e.RawMessage() == internalDeleteNotFoundMessage {
+		return types.ErrNotFound
+	}
+
+	if e.UserFacingError != nil {
+		return fmt.Errorf("user facing error: %w", types.FromUserFacingError(e.UserFacingError))
+	}
+
+	return fmt.Errorf("internal error: %s", e.RawMessage())
+}
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TransactionEngine is implemented by engines which support interactive transactions
+type TransactionEngine interface {
+	StartTransaction(ctx context.Context, options TransactionOptions) (string, error)
+	CommitTransaction(ctx context.Context, id string) error
+	RollbackTransaction(ctx context.Context, id string) error
+}
+
+// TransactionOptions configures an interactive transaction
+type TransactionOptions struct {
+	// MaxWait is the maximum time to wait for the transaction to start
+	MaxWait time.Duration
+
+	// Timeout is the maximum time the transaction can run before it is rolled back
+	Timeout time.Duration
+
+	// IsolationLevel sets the isolation level of the transaction; uses the database default if empty
+	IsolationLevel string
+}
+
+type transactionKey struct{}
+
+// WithTransactionID returns a copy of ctx which carries the given interactive transaction id,
+// so that all queries sent with this context are executed in the transaction
+func WithTransactionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, transactionKey{}, id)
+}
+
+// TransactionID returns the interactive transaction id carried by ctx, if any
+func TransactionID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(transactionKey{}).(string)
+	return id, ok && id != ""
+}
+
+type startTransactionRequest struct {
+	MaxWait        int64  `json:"max_wait"`
+	Timeout        int64  `json:"timeout"`
+	IsolationLevel string `json:"isolation_level,omitempty"`
+}
+
+type startTransactionResponse struct {
+	ID string `json:"id"`
+}
+
+// StartTransaction starts an interactive transaction and returns its id
+func (e *QueryEngine) StartTransaction(ctx context.Context, options TransactionOptions) (string, error) {
+	payload := startTransactionRequest{
+		MaxWait:        options.MaxWait.Milliseconds(),
+		Timeout:        options.Timeout.Milliseconds(),
+		IsolationLevel: options.IsolationLevel,
+	}
+
+	body, err := e.Request(ctx, "POST", "/transaction/start", payload, true)
+	if err != nil {
+		return "", fmt.Errorf("start transaction: %w", err)
+	}
+
+	var response startTransactionResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("start transaction response unmarshal: %w", err)
+	}
+
+	if response.ID == "" {
+		return "", fmt.Errorf("start transaction: no transaction id in response %s", body)
+	}
+
+	return response.ID, nil
+}
+
+// CommitTransaction commits the interactive transaction with the given id
+func (e *QueryEngine) CommitTransaction(ctx context.Context, id string) error {
+	if _, err := e.Request(ctx, "POST", "/transaction/"+id+"/commit", struct{}{}, true); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RollbackTransaction rolls back the interactive transaction with the given id
+func (e *QueryEngine) RollbackTransaction(ctx context.Context, id string) error {
+	if _, err := e.Request(ctx, "POST", "/transaction/"+id+"/rollback", struct{}{}, true); err != nil {
+		return fmt.Errorf("rollback transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryEngine_interactiveTransaction(t *testing.T) {
+	type request struct {
+		path string
+		body string
+		txID string
+	}
+	var requests []request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{
+			path: r.URL.Path,
+			body: string(body),
+			txID: r.Header.Get("X-transaction-id"),
+		})
+		switch r.URL.Path {
+		case "/transaction/start":
+			_, _ = w.Write([]byte(`{"id":"tx1"}`))
+		case "/":
+			_, _ = w.Write([]byte(`{"data":{"result":{"id":"a"}}}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	e := &QueryEngine{
+		http:      server.Client(),
+		httpURL:   server.URL,
+		connected: true,
+	}
+
+	ctx := context.Background()
+
+	id, err := e.StartTransaction(ctx, TransactionOptions{
+		MaxWait:        time.Second,
+		Timeout:        3 * time.Second,
+		IsolationLevel: "Serializable",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "tx1", id)
+
+	var result json.RawMessage
+	assert.NoError(t, e.Do(WithTransactionID(ctx, id), map[string]string{"query": "q"}, &result))
+	assert.NoError(t, e.Do(ctx, map[string]string{"query": "q"}, &result))
+	assert.NoError(t, e.CommitTransaction(ctx, id))
+	assert.NoError(t, e.RollbackTransaction(ctx, id))
+
+	assert.Equal(t, []request{{
+		path: "/transaction/start",
+		body: `{"max_wait":1000,"timeout":3000,"isolation_level":"Serializable"}`,
+	}, {
+		path: "/",
+		body: `{"query":"q"}`,
+		txID: "tx1",
+	}, {
+		path: "/",
+		body: `{"query":"q"}`,
+	}, {
+		path: "/transaction/tx1/commit",
+		body: `{}`,
+	}, {
+		path: "/transaction/tx1/rollback",
+		body: `{}`,
+	}}, requests)
+}
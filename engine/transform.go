@@ -38,6 +38,15 @@ func TransformSQLResponse(data []byte) ([]byte, error) {
 	return o, nil
 }
 
+// RawExtendedJSON holds a MongoDB raw query result in Extended JSON. Results decoded into it are passed through
+// without being transformed, so the caller can decode the BSON types properly.
+type RawExtendedJSON []byte
+
+func (r *RawExtendedJSON) UnmarshalJSON(data []byte) error {
+	*r = append((*r)[:0], data...)
+	return nil
+}
+
 func TransformMongoResponse(data []byte) ([]byte, error) {
 	var result []map[string]interface{}
 
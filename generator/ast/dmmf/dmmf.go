@@ -1,10 +1,13 @@
 package dmmf
 
 import (
+	"encoding/json"
+	"regexp"
+
 	"github.com/steebchen/prisma-client-go/generator/types"
 )
 
-// FieldKind describes a scalar, object or enum.
+// FieldKind describes a scalar, object, enum or composite type.
 type FieldKind string
 
 // FieldKind values
@@ -12,6 +15,9 @@ const (
 	FieldKindScalar FieldKind = "scalar"
 	FieldKindObject FieldKind = "object"
 	FieldKindEnum   FieldKind = "enum"
+	// FieldKindComposite is not part of the DMMF, which uses "object" for both relations and composite types.
+	// Fields referencing a composite type are set to this kind when decoding the Datamodel.
+	FieldKindComposite FieldKind = "composite"
 )
 
 // IncludeInStruct shows whether to include a field in a model struct.
@@ -24,6 +30,11 @@ func (v FieldKind) IsRelation() bool {
 	return v == FieldKindObject
 }
 
+// IsComposite returns whether field references a composite type (MongoDB only)
+func (v FieldKind) IsComposite() bool {
+	return v == FieldKindComposite
+}
+
 // DatamodelFieldKind describes a scalar, object or enum.
 type DatamodelFieldKind string
 
@@ -59,21 +70,34 @@ type Mappings struct {
 	} `json:"otherOperations"`
 }
 
+// Model returns the operations available for the given model.
+// It returns an empty ModelOperation if the model could not be found.
+func (m Mappings) Model(name types.String) ModelOperation {
+	for _, op := range m.ModelOperations {
+		if op.Model == name {
+			return op
+		}
+	}
+	return ModelOperation{}
+}
+
 type ModelOperation struct {
-	Model        types.String `json:"model"`
-	Aggregate    types.String `json:"aggregate"`
-	CreateOne    types.String `json:"createOne"`
-	DeleteMany   types.String `json:"deleteMany"`
-	DeleteOne    types.String `json:"deleteOne"`
-	FindFirst    types.String `json:"findFirst"`
-	FindMany     types.String `json:"findMany"`
-	FindUnique   types.String `json:"findUnique"`
-	GroupBy      types.String `json:"groupBy"`
-	UpdateMany   types.String `json:"updateMany"`
-	UpdateOne    types.String `json:"updateOne"`
-	UpsertOne    types.String `json:"upsertOne"`
-	FindRaw      types.String `json:"findRaw"`      // MongoDB only
-	AggregateRaw types.String `json:"aggregateRaw"` // MongoDB only
+	Model               types.String `json:"model"`
+	Aggregate           types.String `json:"aggregate"`
+	CreateOne           types.String `json:"createOne"`
+	CreateMany          types.String `json:"createMany"`
+	CreateManyAndReturn types.String `json:"createManyAndReturn"` // only on providers which support RETURNING
+	DeleteMany          types.String `json:"deleteMany"`
+	DeleteOne           types.String `json:"deleteOne"`
+	FindFirst           types.String `json:"findFirst"`
+	FindMany            types.String `json:"findMany"`
+	FindUnique          types.String `json:"findUnique"`
+	GroupBy             types.String `json:"groupBy"`
+	UpdateMany          types.String `json:"updateMany"`
+	UpdateOne           types.String `json:"updateOne"`
+	UpsertOne           types.String `json:"upsertOne"`
+	FindRaw             types.String `json:"findRaw"`      // MongoDB only
+	AggregateRaw        types.String `json:"aggregateRaw"` // MongoDB only
 }
 
 func (m *ModelOperation) Namespace() string {
@@ -123,8 +147,10 @@ type ActionType struct {
 	ReturnList bool
 }
 
+// Types returns the kinds of transaction results: Unique returns a single record, Many returns a batch count and
+// List returns multiple records.
 func (Document) Types() []string {
-	return []string{"Unique", "Many"}
+	return []string{"Unique", "Many", "List"}
 }
 
 // Variations contains different query capabilities such as Unique, First and Many
@@ -196,6 +222,52 @@ func (Document) WriteTypes() []Type {
 	}}
 }
 
+// AggregateTypes returns the names of all aggregations, such as Sum or Max
+func (Document) AggregateTypes() []string {
+	return []string{"Count", "Avg", "Sum", "Min", "Max"}
+}
+
+// AggregateFilters returns the filters which can be applied to an aggregated value, e.g. in a having clause
+func (Document) AggregateFilters() []Method {
+	return []Method{{
+		Name:   "Equals",
+		Action: "equals",
+	}, {
+		Name:   "Lt",
+		Action: "lt",
+	}, {
+		Name:   "Lte",
+		Action: "lte",
+	}, {
+		Name:   "Gt",
+		Action: "gt",
+	}, {
+		Name:   "Gte",
+		Action: "gte",
+	}}
+}
+
+// SupportsNullsOrder returns whether the database supports sorting null values first or last, which is the case
+// when the internal NullsOrder enum is present
+func (d Document) SupportsNullsOrder() bool {
+	return d.hasPrismaEnum("NullsOrder")
+}
+
+// SupportsIsolationLevel returns whether the database supports setting the isolation level of transactions, which
+// is the case when the internal TransactionIsolationLevel enum is present
+func (d Document) SupportsIsolationLevel() bool {
+	return d.hasPrismaEnum("TransactionIsolationLevel")
+}
+
+func (d Document) hasPrismaEnum(name types.String) bool {
+	for _, enum := range d.Schema.EnumTypes.Prisma {
+		if enum.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // SchemaEnum describes an enumerated internal prisma type.
 type SchemaEnum struct {
 	Name   types.String   `json:"name"`
@@ -223,6 +295,74 @@ type EnumValue struct {
 type Datamodel struct {
 	Models []Model `json:"models"`
 	Enums  []Enum  `json:"enums"`
+	// Types contains composite types, which are embedded in models (MongoDB only)
+	Types []Model `json:"types"`
+	// Views contains views, if they are listed separately from the models. They are added to Models when decoding
+	// the Datamodel, see Model.IsView.
+	Views []Model `json:"views"`
+}
+
+// viewPattern matches the view blocks of a Prisma schema
+var viewPattern = regexp.MustCompile(`(?m)^\s*view\s+(\w+)\s*\{`)
+
+// MarkViews marks the models which are declared as views in the given Prisma schema, see Model.IsView.
+// Depending on the Prisma version, the DMMF lists views as models without telling them apart, so the schema is
+// the only reliable source.
+func (d *Datamodel) MarkViews(schema string) {
+	for _, match := range viewPattern.FindAllStringSubmatch(schema, -1) {
+		for i := range d.Models {
+			if d.Models[i].Name.String() == match[1] {
+				d.Models[i].IsView = true
+			}
+		}
+	}
+}
+
+// UnmarshalJSON decodes the datamodel and sets the kind of all fields which reference a composite type
+// to FieldKindComposite, so they can be told apart from relations, and the type of all @db.ObjectId fields to
+// ObjectID. Views are added to the models and marked with IsView.
+func (d *Datamodel) UnmarshalJSON(data []byte) error {
+	type datamodel Datamodel
+	var v datamodel
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*d = Datamodel(v)
+
+	for _, view := range d.Views {
+		view.IsView = true
+		found := false
+		for i := range d.Models {
+			if d.Models[i].Name == view.Name {
+				d.Models[i].IsView = true
+				found = true
+			}
+		}
+		if !found {
+			d.Models = append(d.Models, view)
+		}
+	}
+
+	composites := make(map[types.Type]bool, len(d.Types))
+	for _, t := range d.Types {
+		composites[types.Type(t.Name)] = true
+	}
+
+	for _, models := range [][]Model{d.Models, d.Types} {
+		for _, model := range models {
+			for i, field := range model.Fields {
+				if field.Kind == FieldKindObject && composites[field.Type] {
+					model.Fields[i].Kind = FieldKindComposite
+				}
+				// ObjectIDs are strings in the DMMF, but get a dedicated Go type
+				if field.Kind == FieldKindScalar && field.Type == "String" && field.NativeType.Name() == "ObjectId" {
+					model.Fields[i].Type = types.ObjectID
+				}
+			}
+		}
+	}
+
+	return nil
 }
 
 type UniqueIndex struct {
@@ -240,6 +380,11 @@ type Model struct {
 	Fields        []Field       `json:"fields"`
 	UniqueIndexes []UniqueIndex `json:"uniqueIndexes"`
 	PrimaryKey    PrimaryKey    `json:"primaryKey"`
+	// Documentation holds the triple-slash comments of the model
+	Documentation string `json:"documentation"`
+	// IsView is true if the model is a view, which is read-only. It is not part of the DMMF, but set from the views
+	// of the Datamodel and the view blocks of the schema, see Datamodel.MarkViews.
+	IsView bool `json:"-"`
 }
 
 type PrimaryKey struct {
@@ -286,6 +431,8 @@ type Field struct {
 	DBName      types.String `json:"dBName"`
 	IsGenerated bool         `json:"isGenerated"`
 	IsUpdatedAt bool         `json:"isUpdatedAt"`
+	// RelationFromFields (optional) holds the fields which store the relation
+	RelationFromFields []types.String `json:"relationFromFields"`
 	// RelationToFields (optional)
 	RelationToFields []interface{} `json:"relationToFields"`
 	// RelationOnDelete (optional)
@@ -294,6 +441,20 @@ type Field struct {
 	RelationName types.String `json:"relationName"`
 	// HasDefaultValue
 	HasDefaultValue bool `json:"hasDefaultValue"`
+	// NativeType (optional) describes a database-specific type, e.g. @db.ObjectId
+	NativeType NativeType `json:"nativeType"`
+}
+
+// NativeType describes a database-specific type such as @db.ObjectId, encoded as [name, [args...]]
+type NativeType []interface{}
+
+// Name returns the name of the native type, e.g. ObjectId
+func (t NativeType) Name() string {
+	if len(t) == 0 {
+		return ""
+	}
+	name, _ := t[0].(string)
+	return name
 }
 
 func (f Field) RequiredOnCreate(key PrimaryKey) bool {
@@ -312,6 +473,64 @@ func (f Field) RequiredOnCreate(key PrimaryKey) bool {
 	return true
 }
 
+// Aggregate describes an aggregation which can be applied to a field, such as _sum or _max
+type Aggregate struct {
+	// Name of the aggregation to use publicly, such as `Sum`
+	Name string
+	// Action of the aggregation for internal use in the query engine, such as `_sum`
+	Action string
+	// Type describes the type of the aggregated value, e.g. Float for the average of an Int field
+	Type types.Type
+}
+
+// Aggregates returns the aggregations which the query engine supports for this field
+func (f Field) Aggregates() []Aggregate {
+	if !f.Kind.IncludeInStruct() {
+		return nil
+	}
+
+	aggregates := []Aggregate{{
+		Name:   "Count",
+		Action: "_count",
+		Type:   "Int",
+	}}
+
+	if f.IsList {
+		return aggregates
+	}
+
+	switch f.Type {
+	case "Int", "Float", "BigInt", "Decimal":
+		avg := types.Type("Float")
+		if f.Type == "Decimal" {
+			avg = "Decimal"
+		}
+		aggregates = append(aggregates, Aggregate{
+			Name:   "Avg",
+			Action: "_avg",
+			Type:   avg,
+		}, Aggregate{
+			Name:   "Sum",
+			Action: "_sum",
+			Type:   f.Type,
+		})
+	}
+
+	if f.Type != "Json" && f.Type != "Bytes" {
+		aggregates = append(aggregates, Aggregate{
+			Name:   "Min",
+			Action: "_min",
+			Type:   f.Type,
+		}, Aggregate{
+			Name:   "Max",
+			Action: "_max",
+			Type:   f.Type,
+		})
+	}
+
+	return aggregates
+}
+
 // RelationMethod describes a method for relations
 type RelationMethod struct {
 	Name   string
@@ -339,6 +558,30 @@ func (f Field) RelationMethods() []RelationMethod {
 	}}
 }
 
+// CompositeMethods returns a mapping for the PQL filters provided for composite types
+func (f Field) CompositeMethods() []RelationMethod {
+	if f.IsList {
+		return []RelationMethod{{
+			Name:   "Every",
+			Action: "every",
+		}, {
+			Name:   "Some",
+			Action: "some",
+		}, {
+			Name:   "None",
+			Action: "none",
+		}}
+	}
+
+	return []RelationMethod{{
+		Name:   "Is",
+		Action: "is",
+	}, {
+		Name:   "IsNot",
+		Action: "isNot",
+	}}
+}
+
 // Schema provides the GraphQL/PQL AST.
 type Schema struct {
 	// RootQueryType (optional)
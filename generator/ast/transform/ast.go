@@ -42,6 +42,10 @@ func New(document *dmmf.Document) *AST {
 	ast.ReadFilters = ast.readFilters()
 	ast.WriteFilters = ast.writeFilters()
 
+	// ObjectIDs use the String filters of the query engine, but are typed separately
+	ast.ReadFilters = append(ast.ReadFilters, objectIDFilters(ast.ReadFilters)...)
+	ast.WriteFilters = append(ast.WriteFilters, objectIDFilters(ast.WriteFilters)...)
+
 	// add old, deprecated filters which are just added for compatibility reasons
 	// these can be removed at some point
 	for _, filter := range ast.deprecatedReadFilters() {
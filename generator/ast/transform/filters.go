@@ -1,6 +1,8 @@
 package transform
 
 import (
+	"strings"
+
 	"github.com/steebchen/prisma-client-go/generator/types"
 )
 
@@ -26,3 +28,30 @@ type Filter struct {
 	// Methods describe filter methods, such as `Equals`, `In` or `Contains`
 	Methods []Method
 }
+
+// objectIDFilters derives the filters of ObjectID fields from the String filters, as the query engine treats
+// ObjectIDs as strings. String-specific methods such as Contains are left out.
+func objectIDFilters(filters []Filter) []Filter {
+	var result []Filter
+	for _, filter := range filters {
+		if filter.Name != "String" && filter.Name != "String"+list {
+			continue
+		}
+		var methods []Method
+		for _, method := range filter.Methods {
+			switch method.Action {
+			case "contains", "startsWith", "endsWith", "mode", "search":
+				continue
+			}
+			if method.Type == "String" {
+				method.Type = types.ObjectID
+			}
+			methods = append(methods, method)
+		}
+		result = append(result, Filter{
+			Name:    types.ObjectID.String() + strings.TrimPrefix(filter.Name, "String"),
+			Methods: methods,
+		})
+	}
+	return result
+}
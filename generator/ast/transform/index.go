@@ -57,3 +57,72 @@ func getName(field string, fields []types.String) types.String {
 	}
 	return types.String(name)
 }
+
+// CursorKey returns the unique key which is used to paginate over all records with a cursor, i.e. the id field,
+// the compound primary key, or otherwise the first unique field or index of which all fields are required.
+// Returns nil if the model has no such key.
+func (m Model) CursorKey() *Index {
+	keys := m.CursorKeys()
+	if len(keys) == 0 {
+		return nil
+	}
+	return &keys[0]
+}
+
+// CursorKeys returns all unique keys which can be used to paginate over all records with a cursor, i.e. unique
+// fields and indexes of which all fields are required, starting with the one returned by CursorKey.
+func (m Model) CursorKeys() []Index {
+	var candidates []Index
+
+	for _, field := range m.Fields {
+		if field.IsID {
+			candidates = append(candidates, Index{
+				Name:         field.Name,
+				InternalName: field.Name.String(),
+				Fields:       []types.String{field.Name},
+			})
+		}
+	}
+
+	if len(m.OldModel.PrimaryKey.Fields) > 0 {
+		candidates = append(candidates, Index{
+			Name:         types.String(concatFieldsToName(m.OldModel.PrimaryKey.Fields)),
+			InternalName: concatFieldsToName(m.OldModel.PrimaryKey.Fields),
+			Fields:       m.OldModel.PrimaryKey.Fields,
+		})
+	}
+
+	for _, field := range m.Fields {
+		if field.IsUnique {
+			candidates = append(candidates, Index{
+				Name:         field.Name,
+				InternalName: field.Name.String(),
+				Fields:       []types.String{field.Name},
+			})
+		}
+	}
+
+	candidates = append(candidates, m.Indexes...)
+
+	var keys []Index
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if seen[candidate.InternalName] || !m.hasRequiredFields(candidate.Fields) {
+			continue
+		}
+		seen[candidate.InternalName] = true
+		keys = append(keys, candidate)
+	}
+
+	return keys
+}
+
+func (m Model) hasRequiredFields(names []types.String) bool {
+	for _, name := range names {
+		field := m.FieldByName(name)
+		if field == nil || !field.IsRequired || field.IsList || !field.Kind.IncludeInStruct() {
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,9 @@
 package transform
 
 import (
+	"fmt"
+	"regexp"
+
 	"github.com/steebchen/prisma-client-go/generator/ast/dmmf"
 	"github.com/steebchen/prisma-client-go/generator/types"
 )
@@ -10,6 +13,13 @@ type Model struct {
 	Fields  []Field      `json:"fields"`
 	Indexes []Index      `json:"indexes"`
 
+	// ReadOnly is true for views, which only support read operations
+	ReadOnly bool `json:"readOnly"`
+
+	// SoftDelete is the name of the field which marks records as deleted, set with the `/// @soft-delete(field)`
+	// annotation. If set, deletes only set the field, and find queries exclude deleted records.
+	SoftDelete types.String `json:"softDelete"`
+
 	// TODO remove this and apply all required data directly to model
 	OldModel dmmf.Model `json:"-"`
 }
@@ -29,6 +39,127 @@ func (m Model) CompoundKeys() []Index {
 	return items
 }
 
+// RelationField returns the relation field with the given relation name, excluding the field with the given name,
+// which is used to find the opposite field of a relation (including self-relations)
+func (m Model) RelationField(relationName types.String, exclude types.String) *Field {
+	for _, field := range m.Fields {
+		if field.Kind.IsRelation() && field.RelationName == relationName && field.Name != exclude {
+			return &field
+		}
+	}
+	return nil
+}
+
+// FieldByName returns the field with the given name, or nil if it doesn't exist
+func (m Model) FieldByName(name types.String) *Field {
+	for _, field := range m.Fields {
+		if field.Name == name {
+			return &field
+		}
+	}
+	return nil
+}
+
+// ListRelationFields returns all relation fields which are lists, i.e. the relations which can be counted
+func (m Model) ListRelationFields() []Field {
+	var fields []Field
+	for _, field := range m.Fields {
+		if field.Kind.IsRelation() && field.IsList {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Constraint is a database constraint of a model with the name Prisma gives it by default
+type Constraint struct {
+	Name   string
+	Fields []types.String
+}
+
+// Table returns the name of the database table of the model
+func (m Model) Table() string {
+	if m.OldModel.DBName != "" {
+		return m.OldModel.DBName.String()
+	}
+	return m.Name.String()
+}
+
+// ScalarFields returns all fields which are stored in a column of the model
+func (m Model) ScalarFields() []Field {
+	var fields []Field
+	for _, field := range m.Fields {
+		if field.Kind.IncludeInStruct() && !field.Prisma {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Constraints returns the primary key, unique and foreign key constraints of the model with their default names,
+// such as User_email_key, which are used to map the targets of constraint errors back to fields
+func (m Model) Constraints() []Constraint {
+	name := func(fields []types.String, suffix string) string {
+		name := m.Table()
+		for _, f := range fields {
+			column := f.String()
+			if field := m.FieldByName(f); field != nil {
+				column = field.Column()
+			}
+			name += "_" + column
+		}
+		return name + "_" + suffix
+	}
+
+	var constraints []Constraint
+	primary := m.OldModel.PrimaryKey.Fields
+	for _, field := range m.Fields {
+		if field.IsID {
+			primary = []types.String{field.Name}
+		}
+	}
+	if len(primary) > 0 {
+		constraints = append(constraints, Constraint{
+			Name:   m.Table() + "_pkey",
+			Fields: primary,
+		})
+	}
+
+	for _, field := range m.Fields {
+		if field.IsUnique {
+			fields := []types.String{field.Name}
+			constraints = append(constraints, Constraint{
+				Name:   name(fields, "key"),
+				Fields: fields,
+			})
+		}
+		if field.Kind.IsRelation() && len(field.RelationFromFields) > 0 {
+			constraints = append(constraints, Constraint{
+				Name:   name(field.RelationFromFields, "fkey"),
+				Fields: field.RelationFromFields,
+			})
+		}
+	}
+
+	for _, index := range m.OldModel.UniqueIndexes {
+		constraints = append(constraints, Constraint{
+			Name:   name(index.Fields, "key"),
+			Fields: index.Fields,
+		})
+	}
+
+	// @@unique on a single field results in the same constraint as @unique
+	seen := make(map[string]bool)
+	var unique []Constraint
+	for _, c := range constraints {
+		if !seen[c.Name] {
+			seen[c.Name] = true
+			unique = append(unique, c)
+		}
+	}
+	return unique
+}
+
 type Field struct {
 	// TODO re-declare all fields here instead of embedding dmmf.Field
 
@@ -38,6 +169,45 @@ type Field struct {
 	dmmf.Field
 }
 
+// Column returns the name of the database column of the field
+func (f Field) Column() string {
+	if f.DBName != "" {
+		return f.DBName.String()
+	}
+	return f.Name.String()
+}
+
+var softDeleteAnnotation = regexp.MustCompile(`@soft-delete\(\s*(\w+)\s*\)`)
+
+// softDeleteField returns the field of the `@soft-delete(field)` annotation in the documentation of a model
+func softDeleteField(documentation string) types.String {
+	match := softDeleteAnnotation.FindStringSubmatch(documentation)
+	if match == nil {
+		return ""
+	}
+	return types.String(match[1])
+}
+
+// Validate checks the annotations of the models
+func (r *AST) Validate() error {
+	for _, model := range r.Models {
+		if model.SoftDelete == "" {
+			continue
+		}
+		if model.ReadOnly {
+			return fmt.Errorf("@soft-delete on %s: views can not be soft deleted", model.Name)
+		}
+		field := model.FieldByName(model.SoftDelete)
+		if field == nil {
+			return fmt.Errorf("@soft-delete on %s: field %q does not exist", model.Name, model.SoftDelete)
+		}
+		if field.Type != "DateTime" || field.IsRequired || field.IsList {
+			return fmt.Errorf("@soft-delete on %s: field %q must be an optional DateTime", model.Name, model.SoftDelete)
+		}
+	}
+	return nil
+}
+
 func (r *AST) models() []Model {
 	var models []Model
 	for _, model := range r.dmmf.Datamodel.Models {
@@ -48,12 +218,24 @@ func (r *AST) models() []Model {
 			})
 		}
 		m := Model{
-			Name:     model.Name,
-			Fields:   fields,
-			OldModel: model,
+			Name:       model.Name,
+			Fields:     fields,
+			ReadOnly:   model.IsView,
+			SoftDelete: softDeleteField(model.Documentation),
+			OldModel:   model,
 		}
 		m.Indexes = indexes(model)
 		models = append(models, m)
 	}
 	return models
 }
+
+// Model returns the model with the given name
+func (r *AST) Model(name string) *Model {
+	for _, model := range r.Models {
+		if model.Name.String() == name {
+			return &model
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package transform
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/generator/ast/dmmf"
+)
+
+func TestModelsReadOnly(t *testing.T) {
+	model := func(name string) string {
+		return `{"name":"` + name + `","fields":[{"name":"id","kind":"scalar","type":"String","isRequired":true,"isUnique":true}]}`
+	}
+	// the mappings contain write operations for all models, so views can only be told apart by the datamodel
+	mappings := `"mappings":{"modelOperations":[
+		{"model":"User","createOne":"createOneUser","updateOne":"updateOneUser","deleteOne":"deleteOneUser"},
+		{"model":"Stats","createOne":"createOneStats","updateOne":"updateOneStats","deleteOne":"deleteOneStats"}
+	]}`
+
+	tests := []struct {
+		name   string
+		dmmf   string
+		schema string
+	}{{
+		name:   "view block in the schema",
+		dmmf:   `{"datamodel":{"models":[` + model("User") + `,` + model("Stats") + `]},` + mappings + `}`,
+		schema: "model User {\n  id String @id\n}\n\nview Stats {\n  id String @unique\n}\n",
+	}, {
+		name:   "views of the datamodel",
+		dmmf:   `{"datamodel":{"models":[` + model("User") + `],"views":[` + model("Stats") + `]},` + mappings + `}`,
+		schema: "model User {\n  id String @id\n}\n",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document dmmf.Document
+			if err := json.Unmarshal([]byte(tt.dmmf), &document); err != nil {
+				t.Fatal(err)
+			}
+			document.Datamodel.MarkViews(tt.schema)
+
+			readOnly := map[string]bool{}
+			for _, m := range New(&document).Models {
+				readOnly[m.Name.String()] = m.ReadOnly
+			}
+
+			want := map[string]bool{"User": false, "Stats": true}
+			for name, expected := range want {
+				actual, ok := readOnly[name]
+				if !ok {
+					t.Fatalf("model %s is missing", name)
+				}
+				if actual != expected {
+					t.Errorf("%s: expected ReadOnly to be %v, got %v", name, expected, actual)
+				}
+			}
+		})
+	}
+}
@@ -39,6 +39,10 @@ func addDefaults(input *Root) {
 func Run(input *Root) error {
 	addDefaults(input)
 
+	if err := input.AST.Validate(); err != nil {
+		return err
+	}
+
 	if input.Version != binaries.EngineVersion {
 		fmt.Printf("\nwarning: prisma CLI version mismatch detected. CLI version: %s, internal version: %s (%s); please see https://github.com/steebchen/prisma-client-go/issues/1099 for details\n\n", input.Version, binaries.EngineVersion, binaries.PrismaVersion)
 	}
@@ -81,10 +85,16 @@ func generateClient(input *Root) error {
 		"fields",
 		"mock",
 		"models",
+		"hooks",
+		"composite",
 		"query",
 		"actions/actions",
 		"actions/create",
 		"actions/find",
+		"actions/aggregate",
+		"actions/iter",
+		"actions/paginate",
+		"actions/parse",
 		"actions/transaction",
 		"actions/upsert",
 		"actions/raw",
@@ -63,6 +63,9 @@ var builtin = map[string]string{
 // Type acts as a builtin string but provides useful methods for type DMMF values.
 type Type string
 
+// ObjectID is the type of @db.ObjectId fields, which are strings in the DMMF
+const ObjectID Type = "ObjectID"
+
 func (t Type) String() string {
 	return string(t)
 }
@@ -0,0 +1,200 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/steebchen/prisma-client-go/engine"
+	"github.com/steebchen/prisma-client-go/engine/protocol"
+	"github.com/steebchen/prisma-client-go/runtime/types"
+)
+
+// ExecBatch sends the given queries in a single request without a transaction and unmarshals the result of each
+// query into the value at the same index of into. All queries need to use the same engine.
+func ExecBatch(ctx context.Context, queries []Query, into []interface{}) error {
+	if len(queries) != len(into) {
+		return fmt.Errorf("got %d queries but %d result values", len(queries), len(into))
+	}
+
+	if len(queries) == 0 {
+		return nil
+	}
+
+	e := queries[0].Engine
+	if e == nil {
+		return fmt.Errorf("client.Prisma.Connect() needs to be called before sending queries")
+	}
+
+	return RunBatch(ctx, e, queries, false, func(ctx context.Context, requests []protocol.GQLRequest) error {
+		results, err := execBatch(ctx, e, requests, into)
+		if err != nil {
+			return err
+		}
+		return RunAfterHooks(ctx, queries, results)
+	})
+}
+
+// BatchItem is the result of a single query of a batch, see ExecBatchItems
+type BatchItem struct {
+	// Data is the raw result of the query, and nil if the query failed
+	Data json.RawMessage
+
+	// Err is the error of the query, if it failed
+	Err error
+}
+
+// Decode decodes the result of the query into v. It returns the error of the query if it failed, and
+// types.ErrNotFound if the result is null, e.g. for a FindUnique query of a record which does not exist.
+func (i BatchItem) Decode(v interface{}) error {
+	if i.Err != nil {
+		return i.Err
+	}
+	if len(i.Data) == 0 || string(i.Data) == "null" {
+		return types.ErrNotFound
+	}
+	if err := json.Unmarshal(i.Data, v); err != nil {
+		return fmt.Errorf("json data result unmarshal: %w", err)
+	}
+	return nil
+}
+
+// ExecBatchItems sends the given queries in a single request without a transaction, like ExecBatch, but returns
+// the result or error of each query separately, so that a failing query does not affect the others. The returned
+// error is only set if the whole batch failed.
+func ExecBatchItems(ctx context.Context, e engine.Engine, queries []Query) ([]BatchItem, error) {
+	if len(queries) == 0 {
+		return nil, nil
+	}
+
+	if e == nil {
+		return nil, fmt.Errorf("client.Prisma.Connect() needs to be called before sending queries")
+	}
+
+	items := make([]BatchItem, len(queries))
+	err := RunBatch(ctx, e, queries, false, func(ctx context.Context, requests []protocol.GQLRequest) error {
+		var result protocol.GQLBatchResponse
+		payload := protocol.GQLBatchRequest{
+			Batch:       requests,
+			Transaction: false,
+		}
+		if err := e.Batch(ctx, payload, &result); err != nil {
+			return fmt.Errorf("could not send batch: %w", err)
+		}
+
+		if len(result.Errors) > 0 {
+			return batchError(result.Errors[0])
+		}
+
+		if len(result.Result) != len(requests) {
+			return fmt.Errorf("expected %d batch results, got %d", len(requests), len(result.Result))
+		}
+
+		for i, inner := range result.Result {
+			if len(inner.Errors) > 0 {
+				items[i].Err = batchError(inner.Errors[0])
+				continue
+			}
+
+			items[i].Data = inner.Data.Result
+			if queries[i].After != nil {
+				if err := queries[i].After(ctx, inner.Data.Result); err != nil {
+					items[i].Err = &AfterHookError{Errs: map[int]error{i: err}}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func execBatch(ctx context.Context, e engine.Engine, requests []protocol.GQLRequest, into []interface{}) ([]json.RawMessage, error) {
+	var result protocol.GQLBatchResponse
+	payload := protocol.GQLBatchRequest{
+		Batch:       requests,
+		Transaction: false,
+	}
+	if err := e.Batch(ctx, payload, &result); err != nil {
+		return nil, fmt.Errorf("could not send batch: %w", err)
+	}
+
+	if len(result.Errors) > 0 {
+		return nil, batchError(result.Errors[0])
+	}
+
+	if len(result.Result) != len(requests) {
+		return nil, fmt.Errorf("expected %d batch results, got %d", len(requests), len(result.Result))
+	}
+
+	results := make([]json.RawMessage, len(result.Result))
+	for i, inner := range result.Result {
+		if len(inner.Errors) > 0 {
+			return nil, batchError(inner.Errors[0])
+		}
+
+		if err := json.Unmarshal(inner.Data.Result, into[i]); err != nil {
+			return nil, fmt.Errorf("json data result unmarshal: %w", err)
+		}
+		results[i] = inner.Data.Result
+	}
+
+	return results, nil
+}
+
+// RunBatch runs the Before functions of the queries and the middleware of the engine, if any, around a batch of
+// queries, and calls exec with the built requests of the queries.
+func RunBatch(
+	ctx context.Context,
+	e engine.Engine,
+	queries []Query,
+	transaction bool,
+	exec func(ctx context.Context, requests []protocol.GQLRequest) error,
+) error {
+	for i := range queries {
+		if queries[i].Before != nil {
+			if err := queries[i].Before(ctx, &queries[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	info := &QueryInfo{
+		Method:      "batch",
+		Operation:   "mutation",
+		Transaction: transaction,
+	}
+	for i := range queries {
+		info.Queries = append(info.Queries, &queries[i])
+	}
+
+	run := func(ctx context.Context) error {
+		requests := make([]protocol.GQLRequest, len(info.Queries))
+		for i, query := range info.Queries {
+			str, err := query.Build()
+			if err != nil {
+				return err
+			}
+			requests[i] = protocol.GQLRequest{
+				Query:     str,
+				Variables: map[string]interface{}{},
+			}
+		}
+		return exec(ctx, requests)
+	}
+
+	chain := middlewareChain(e)
+	if chain == nil {
+		return run(ctx)
+	}
+	return chain.Run(ctx, info, run)
+}
+
+func batchError(e protocol.GQLError) error {
+	if e.UserFacingError != nil {
+		return fmt.Errorf("user facing error: %w", types.FromUserFacingError(e.UserFacingError))
+	}
+	return fmt.Errorf("pql error: %s", e.RawMessage())
+}
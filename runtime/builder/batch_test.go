@@ -0,0 +1,66 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/steebchen/prisma-client-go/engine/protocol"
+	"github.com/steebchen/prisma-client-go/runtime/types"
+)
+
+type itemsEngine struct {
+	batchEngine
+	payload  protocol.GQLBatchRequest
+	response string
+}
+
+func (e *itemsEngine) Batch(ctx context.Context, payload interface{}, into interface{}) error {
+	e.payload = payload.(protocol.GQLBatchRequest)
+	return json.Unmarshal([]byte(e.response), into)
+}
+
+func TestExecBatchItems(t *testing.T) {
+	e := &itemsEngine{
+		response: `{"batchResult":[
+			{"data":{"result":[{"id":"a"}]}},
+			{"errors":[{"error":"failed","user_facing_error":{"message":"failed","error_code":"P2002"}}]},
+			{"data":{"result":null}}
+		]}`,
+	}
+
+	queries := []Query{
+		{Engine: e, Operation: "query", Method: "findMany", Model: "User"},
+		{Engine: e, Operation: "mutation", Method: "createOne", Model: "User"},
+		{Engine: e, Operation: "query", Method: "findUnique", Model: "User"},
+	}
+
+	items, err := ExecBatchItems(context.Background(), e, queries)
+	assert.NoError(t, err)
+	assert.False(t, e.payload.Transaction)
+	assert.Equal(t, 3, len(e.payload.Batch))
+
+	var users []struct {
+		ID string `json:"id"`
+	}
+	assert.NoError(t, items[0].Decode(&users))
+	assert.Equal(t, "a", users[0].ID)
+
+	var ufe *protocol.UserFacingError
+	assert.ErrorAs(t, items[1].Decode(&users), &ufe)
+	assert.Equal(t, "P2002", ufe.ErrorCode)
+
+	assert.ErrorIs(t, items[2].Decode(&users), types.ErrNotFound)
+}
+
+func TestExecBatchItemsFailed(t *testing.T) {
+	e := &itemsEngine{
+		response: `{"errors":[{"error":"failed"}]}`,
+	}
+
+	items, err := ExecBatchItems(context.Background(), e, []Query{{Engine: e, Method: "findMany", Model: "User"}})
+	assert.Error(t, err)
+	assert.Nil(t, items)
+}
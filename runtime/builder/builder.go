@@ -15,14 +15,16 @@ import (
 type MethodFormat string
 
 const (
-	FindRaw      MethodFormat = "findRaw"
-	AggregateRaw MethodFormat = "aggregateRaw"
+	FindRaw             MethodFormat = "findRaw"
+	AggregateRaw        MethodFormat = "aggregateRaw"
+	CreateManyAndReturn MethodFormat = "createManyAndReturn"
 )
 
 var (
 	MethodFormatMaping = map[MethodFormat]string{
-		FindRaw:      "find%sRaw",      // find{Model}Raw
-		AggregateRaw: "aggregate%sRaw", // aggregate{Model}Raw
+		FindRaw:             "find%sRaw",             // find{Model}Raw
+		AggregateRaw:        "aggregate%sRaw",        // aggregate{Model}Raw
+		CreateManyAndReturn: "createMany%sAndReturn", // createMany{Model}AndReturn
 	}
 )
 
@@ -31,6 +33,9 @@ type Input struct {
 	Fields   []Field
 	Value    interface{}
 	WrapList bool
+
+	// Separate saves whether the fields of a wrapped list are separate items which are never merged, see Field
+	Separate bool
 }
 
 // Output can be a single Name or can have nested fields
@@ -53,6 +58,11 @@ type Field struct {
 	// WrapList saves whether the field should be wrapped in an individual object
 	WrapList bool
 
+	// Separate saves whether the fields of a wrapped list are separate items, which are never merged even if they
+	// have the same name, e.g. multiple order by params or the records of a link. Otherwise fields with the same
+	// name are merged, e.g. a contains filter and its mode inside of AND.
+	Separate bool
+
 	// Value contains the field value. if nil, fields will contain a subselection.
 	Value interface{}
 
@@ -92,6 +102,39 @@ type Query struct {
 	Start time.Time
 
 	TxResult chan []byte
+
+	// Before runs before the query is built and sent, including in transactions and batches, and can modify it.
+	// It is used to run lifecycle hooks.
+	Before func(ctx context.Context, q *Query) error
+
+	// After runs with the raw result once the query was executed successfully, including in transactions and batches.
+	// It is used to run lifecycle hooks.
+	After func(ctx context.Context, result []byte) error
+
+	// SoftDelete is the name of the field which marks records of a soft delete model as deleted. If set, records
+	// where the field is not null are excluded, see ScopedInputs.
+	SoftDelete string
+}
+
+// SetInput replaces the fields of the input with the given name, or adds the input if it does not exist yet
+// The inputs are copied, so that copies of the query are not affected.
+func (q *Query) SetInput(name string, fields []Field) {
+	inputs := make([]Input, 0, len(q.Inputs)+1)
+	found := false
+	for _, input := range q.Inputs {
+		if input.Name == name {
+			input.Fields = fields
+			found = true
+		}
+		inputs = append(inputs, input)
+	}
+	if !found {
+		inputs = append(inputs, Input{
+			Name:   name,
+			Fields: fields,
+		})
+	}
+	q.Inputs = inputs
 }
 
 func (q Query) Build() (string, error) {
@@ -119,12 +162,14 @@ func (q Query) BuildInner() (string, error) {
 		builder.WriteString(fmt.Sprintf(MethodFormatMaping[FindRaw], q.Model))
 	case AggregateRaw:
 		builder.WriteString(fmt.Sprintf(MethodFormatMaping[AggregateRaw], q.Model))
+	case CreateManyAndReturn:
+		builder.WriteString(fmt.Sprintf(MethodFormatMaping[CreateManyAndReturn], q.Model))
 	default:
 		builder.WriteString(q.Method + q.Model)
 	}
 
-	if len(q.Inputs) > 0 {
-		str, err := q.buildInputs(q.Inputs)
+	if inputs := q.ScopedInputs(); len(inputs) > 0 {
+		str, err := q.buildInputs(inputs)
 		if err != nil {
 			return "", err
 		}
@@ -155,12 +200,16 @@ func (q Query) buildInputs(inputs []Input) (string, error) {
 		builder.WriteString(":")
 
 		if i.Value != nil {
-			builder.Write(Value(i.Value))
+			v, err := json.Marshal(i.Value)
+			if err != nil {
+				return "", fmt.Errorf("input %s: %w", i.Name, err)
+			}
+			builder.Write(v)
 		} else {
 			if i.WrapList {
 				builder.WriteString("[")
 			}
-			str, err := q.buildFields(i.WrapList, i.WrapList, i.Fields)
+			str, err := q.buildFields(i.WrapList, i.WrapList, i.Separate, i.Fields)
 			if err != nil {
 				return "", err
 			}
@@ -212,7 +261,7 @@ func (q Query) buildOutputs(outputs []Output) (string, error) {
 
 var ErrDuplicateField = fmt.Errorf("duplicate field (https://github.com/steebchen/prisma-client-go/issues/1095)")
 
-func (q Query) buildFields(list bool, wrapList bool, fields []Field) (string, error) {
+func (q Query) buildFields(list bool, wrapList bool, separate bool, fields []Field) (string, error) {
 	var builder strings.Builder
 
 	if !list {
@@ -227,6 +276,13 @@ func (q Query) buildFields(list bool, wrapList bool, fields []Field) (string, er
 	// this is necessary for json filters and more
 	uniques := make(map[string]*Field)
 	for i, f := range fields {
+		// unnamed fields are list items, e.g. records in a createMany input, and fields of separate lists are
+		// individual items, e.g. multiple order by params, so they are never merged
+		if f.Name == "" || separate {
+			final = append(final, f)
+			continue
+		}
+
 		if _, ok := uniques[f.Name]; ok {
 			// check if field is a model operation
 			if f.Fields != nil && f.Name != "AND" && f.Name != "OR" && f.Name != "NOT" {
@@ -253,7 +309,10 @@ func (q Query) buildFields(list bool, wrapList bool, fields []Field) (string, er
 			return "", err
 		}
 
-		if wrapList {
+		// unnamed fields with a subselection are already objects, so they don't need to be wrapped
+		wrap := wrapList && (f.Name != "" || f.Fields == nil)
+
+		if wrap {
 			builder.WriteString("{")
 		}
 
@@ -270,7 +329,7 @@ func (q Query) buildFields(list bool, wrapList bool, fields []Field) (string, er
 		}
 
 		if f.Fields != nil {
-			str, err := q.buildFields(f.List, f.WrapList, f.Fields)
+			str, err := q.buildFields(f.List, f.WrapList, f.Separate, f.Fields)
 			if err != nil {
 				return "", err
 			}
@@ -278,14 +337,18 @@ func (q Query) buildFields(list bool, wrapList bool, fields []Field) (string, er
 		}
 
 		if f.Value != nil {
-			builder.Write(Value(f.Value))
+			v, err := json.Marshal(f.Value)
+			if err != nil {
+				return "", fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			builder.Write(v)
 		}
 
 		if f.List {
 			builder.WriteString("]")
 		}
 
-		if wrapList {
+		if wrap {
 			builder.WriteString("}")
 		}
 
@@ -312,7 +375,50 @@ func checkFields(parent Field, fields []Field) error {
 	return nil
 }
 
+// Exec builds and sends the query and decodes the result into into. If the engine runs middleware, see
+// MiddlewareEngine, the query is executed through it. Before and After run around it.
 func (q Query) Exec(ctx context.Context, into interface{}) error {
+	if q.Before != nil {
+		if err := q.Before(ctx, &q); err != nil {
+			return err
+		}
+	}
+
+	if q.After == nil {
+		return q.run(ctx, into)
+	}
+
+	var data json.RawMessage
+	if err := q.run(ctx, &data); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, into); err != nil {
+		return fmt.Errorf("json data result unmarshal: %w", err)
+	}
+	return RunAfterHooks(ctx, []Query{q}, []json.RawMessage{data})
+}
+
+// run executes the query through the middleware of the engine, if any
+func (q Query) run(ctx context.Context, into interface{}) error {
+	chain := middlewareChain(q.Engine)
+	if chain == nil {
+		return q.exec(ctx, into)
+	}
+
+	info := &QueryInfo{
+		Model:     q.Model,
+		Method:    q.Method,
+		Operation: q.Operation,
+		Query:     &q,
+		Result:    into,
+		Start:     q.Start,
+	}
+	return chain.Run(ctx, info, func(ctx context.Context) error {
+		return info.Query.exec(ctx, into)
+	})
+}
+
+func (q Query) exec(ctx context.Context, into interface{}) error {
 	str, err := q.Build()
 	if err != nil {
 		return err
@@ -321,6 +427,9 @@ func (q Query) Exec(ctx context.Context, into interface{}) error {
 		Query:     str,
 		Variables: map[string]interface{}{},
 	}
+	if l := loaderFor(ctx, q); l != nil {
+		return l.Load(ctx, payload, into)
+	}
 	return q.Do(ctx, payload, into)
 }
 
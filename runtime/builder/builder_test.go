@@ -0,0 +1,132 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildInnerMethodFormat(t *testing.T) {
+	tests := []struct {
+		method   string
+		expected string
+	}{{
+		method:   "createMany",
+		expected: "createManyUser",
+	}, {
+		method:   "createManyAndReturn",
+		expected: "createManyUserAndReturn",
+	}, {
+		method:   "findRaw",
+		expected: "findUserRaw",
+	}, {
+		method:   "aggregateRaw",
+		expected: "aggregateUserRaw",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			q := Query{
+				Method:  tt.method,
+				Model:   "User",
+				Outputs: []Output{{Name: "id"}},
+			}
+			actual, err := q.BuildInner()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected+" {id }", actual)
+		})
+	}
+}
+
+func TestBuildFieldsWrappedList(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputs   []Input
+		expected string
+	}{{
+		name: "same field outside of a list is merged",
+		inputs: []Input{{
+			Name: "where",
+			Fields: []Field{
+				{Name: "name", Fields: []Field{{Name: "contains", Value: "a"}}},
+				{Name: "name", Fields: []Field{{Name: "startsWith", Value: "b"}}},
+			},
+		}},
+		expected: `findManyUser(where:{name:{contains:"a",startsWith:"b",},}) {id }`,
+	}, {
+		name: "and merges contains and mode",
+		inputs: []Input{{
+			Name: "where",
+			Fields: []Field{{
+				Name:     "AND",
+				List:     true,
+				WrapList: true,
+				Fields: []Field{
+					{Name: "name", Fields: []Field{{Name: "contains", Value: "this"}}},
+					{Name: "name", Fields: []Field{{Name: "mode", Value: "insensitive"}}},
+				},
+			}},
+		}},
+		expected: `findManyUser(where:{AND:[{name:{contains:"this",mode:"insensitive",}},],}) {id }`,
+	}, {
+		name: "or merges contains and mode",
+		inputs: []Input{{
+			Name: "where",
+			Fields: []Field{{
+				Name:     "OR",
+				List:     true,
+				WrapList: true,
+				Fields: []Field{
+					{Name: "name", Fields: []Field{{Name: "contains", Value: "this"}}},
+					{Name: "email", Fields: []Field{{Name: "contains", Value: "this"}}},
+					{Name: "name", Fields: []Field{{Name: "mode", Value: "insensitive"}}},
+				},
+			}},
+		}},
+		expected: `findManyUser(where:{OR:[{name:{contains:"this",mode:"insensitive",}},{email:{contains:"this",}},],}) {id }`,
+	}, {
+		name: "multiple order by items are separate",
+		inputs: []Input{{
+			Name:     "orderBy",
+			WrapList: true,
+			Separate: true,
+			Fields: []Field{
+				{Name: "name", Value: "asc"},
+				{Name: "posts", Fields: []Field{{Name: "_count", Value: "desc"}}},
+				{Name: "name", Fields: []Field{{Name: "sort", Value: "desc"}, {Name: "nulls", Value: "last"}}},
+			},
+		}},
+		expected: `findManyUser(orderBy:[{name:"asc"},{posts:{_count:"desc",}},{name:{sort:"desc",nulls:"last",}},]) {id }`,
+	}, {
+		name: "link records are separate",
+		inputs: []Input{{
+			Name: "data",
+			Fields: []Field{{
+				Name: "posts",
+				Fields: []Field{{
+					Name:     "connect",
+					List:     true,
+					WrapList: true,
+					Separate: true,
+					Fields: []Field{
+						{Name: "author_title", Fields: []Field{{Name: "title", Value: "a"}}},
+						{Name: "author_title", Fields: []Field{{Name: "title", Value: "b"}}},
+					},
+				}},
+			}},
+		}},
+		expected: `findManyUser(data:{posts:{connect:[{author_title:{title:"a",}},{author_title:{title:"b",}},],},}) {id }`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := Query{
+				Method:  "findMany",
+				Model:   "User",
+				Inputs:  tt.inputs,
+				Outputs: []Output{{Name: "id"}},
+			}
+			actual, err := q.BuildInner()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
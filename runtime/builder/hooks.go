@@ -0,0 +1,104 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// HookList holds lifecycle hooks which receive a value of type T. It is safe for concurrent use.
+type HookList[T any] struct {
+	mu    sync.RWMutex
+	hooks []func(ctx context.Context, value T) error
+}
+
+// Add registers a hook
+func (l *HookList[T]) Add(hook func(ctx context.Context, value T) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook)
+}
+
+// Len returns the number of registered hooks
+func (l *HookList[T]) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.hooks)
+}
+
+// Run runs all hooks in the order they were registered, and stops at the first error
+func (l *HookList[T]) Run(ctx context.Context, value T) error {
+	l.mu.RLock()
+	hooks := l.hooks
+	l.mu.RUnlock()
+
+	for _, hook := range hooks {
+		if err := hook(ctx, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunDecoded decodes the raw result of a query into a new T and runs the hooks with it.
+// Nothing is decoded if no hooks are registered.
+func RunDecoded[T any](ctx context.Context, l *HookList[*T], data []byte) error {
+	if l.Len() == 0 {
+		return nil
+	}
+	v := new(T)
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode result for hooks: %w", err)
+	}
+	return l.Run(ctx, v)
+}
+
+// AfterHookError is returned when After hooks fail after their queries were executed. The queries already
+// succeeded, and batch transactions were committed, so they must not be retried; only the hooks failed.
+type AfterHookError struct {
+	// Errs maps the index of each query whose hook failed to the error of the hook
+	Errs map[int]error
+}
+
+func (e *AfterHookError) Error() string {
+	indexes := make([]int, 0, len(e.Errs))
+	for i := range e.Errs {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	messages := make([]string, len(indexes))
+	for i, index := range indexes {
+		messages[i] = fmt.Sprintf("query %d: %s", index, e.Errs[index])
+	}
+	return fmt.Sprintf("after hooks failed after the queries were executed: %s", strings.Join(messages, "; "))
+}
+
+func (e *AfterHookError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// RunAfterHooks runs the After hooks of all queries with their results. All hooks run even if some of them fail,
+// and their errors are returned as an AfterHookError.
+func RunAfterHooks(ctx context.Context, queries []Query, results []json.RawMessage) error {
+	errs := make(map[int]error)
+	for i, q := range queries {
+		if q.After == nil {
+			continue
+		}
+		if err := q.After(ctx, results[i]); err != nil {
+			errs[i] = err
+		}
+	}
+	if len(errs) > 0 {
+		return &AfterHookError{Errs: errs}
+	}
+	return nil
+}
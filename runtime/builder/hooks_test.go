@@ -0,0 +1,92 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHookList(t *testing.T) {
+	errStop := errors.New("stop")
+
+	var log []string
+	var hooks HookList[*[]string]
+	hooks.Add(func(ctx context.Context, value *[]string) error {
+		*value = append(*value, "a")
+		return nil
+	})
+	hooks.Add(func(ctx context.Context, value *[]string) error {
+		log = append(log, (*value)...)
+		return errStop
+	})
+	hooks.Add(func(ctx context.Context, value *[]string) error {
+		t.Fatal("hook after an error must not run")
+		return nil
+	})
+
+	var value []string
+	err := hooks.Run(context.Background(), &value)
+
+	assert.Equal(t, errStop, err)
+	assert.Equal(t, []string{"a"}, log)
+	assert.Equal(t, 3, hooks.Len())
+}
+
+func TestRunDecoded(t *testing.T) {
+	type record struct {
+		ID string `json:"id"`
+	}
+
+	var actual *record
+	var hooks HookList[*record]
+	hooks.Add(func(ctx context.Context, value *record) error {
+		actual = value
+		return nil
+	})
+
+	err := RunDecoded(context.Background(), &hooks, []byte(`{"id":"a"}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &record{ID: "a"}, actual)
+}
+
+func TestRunAfterHooks(t *testing.T) {
+	errA := errors.New("a")
+	errC := errors.New("c")
+
+	var ran []string
+	hook := func(name string, err error) func(ctx context.Context, result []byte) error {
+		return func(ctx context.Context, result []byte) error {
+			ran = append(ran, name+string(result))
+			return err
+		}
+	}
+	queries := []Query{
+		{After: hook("a", errA)},
+		{After: hook("b", nil)},
+		{After: hook("c", errC)},
+		{},
+	}
+
+	err := RunAfterHooks(context.Background(), queries, []json.RawMessage{
+		json.RawMessage("1"),
+		json.RawMessage("2"),
+		json.RawMessage("3"),
+		json.RawMessage("4"),
+	})
+
+	// all hooks run even if one fails
+	assert.Equal(t, []string{"a1", "b2", "c3"}, ran)
+
+	var hookErr *AfterHookError
+	assert.ErrorAs(t, err, &hookErr)
+	assert.Equal(t, map[int]error{0: errA, 2: errC}, hookErr.Errs)
+	assert.ErrorIs(t, err, errA)
+	assert.ErrorIs(t, err, errC)
+	assert.EqualError(t, err, "after hooks failed after the queries were executed: query 0: a; query 2: c")
+
+	assert.NoError(t, RunAfterHooks(context.Background(), queries[1:2], []json.RawMessage{json.RawMessage("2")}))
+}
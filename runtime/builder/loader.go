@@ -0,0 +1,165 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/engine"
+	"github.com/steebchen/prisma-client-go/engine/protocol"
+)
+
+// DefaultLoaderWait is how long a Loader collects queries before sending them
+const DefaultLoaderWait = 2 * time.Millisecond
+
+// DefaultLoaderMaxBatch is the number of queries after which a Loader sends a batch without waiting
+const DefaultLoaderMaxBatch = 100
+
+// Loader coalesces findUnique queries which are executed concurrently with the same context into a single
+// non-transactional batch request, which the query engine compacts into a single findMany query.
+// A loader is usually created per request, see WithLoader.
+type Loader struct {
+	// Wait is how long queries are collected before they are sent
+	Wait time.Duration
+
+	// MaxBatch is the number of queries after which a batch is sent without waiting
+	MaxBatch int
+
+	ctx    context.Context
+	engine engine.Engine
+
+	mu      sync.Mutex
+	pending []*loaderCall
+	timer   *time.Timer
+}
+
+type loaderCall struct {
+	request protocol.GQLRequest
+	done    chan struct{}
+	data    json.RawMessage
+	err     error
+}
+
+// NewLoader creates a loader for the queries of the given engine. Batches are sent with ctx, so that they are not
+// cancelled if a single caller cancels its query.
+func NewLoader(ctx context.Context, e engine.Engine) *Loader {
+	return &Loader{
+		Wait:     DefaultLoaderWait,
+		MaxBatch: DefaultLoaderMaxBatch,
+		ctx:      ctx,
+		engine:   e,
+	}
+}
+
+type loaderKey struct{}
+
+// WithLoader returns a copy of ctx which carries the loader. findUnique queries of the engine of the loader which
+// are executed with the returned context are coalesced.
+func WithLoader(ctx context.Context, l *Loader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+// loaderFor returns the loader of the context if it coalesces the given query. Queries which join an interactive
+// transaction with their context are not coalesced, as batches are sent with the context of the loader.
+func loaderFor(ctx context.Context, q Query) *Loader {
+	l, ok := ctx.Value(loaderKey{}).(*Loader)
+	if !ok || q.Method != "findUnique" || q.TxResult != nil || q.Engine != l.engine {
+		return nil
+	}
+	if id, ok := engine.TransactionID(ctx); ok {
+		if loaderID, _ := engine.TransactionID(l.ctx); loaderID != id {
+			return nil
+		}
+	}
+	return l
+}
+
+// Load adds the request to the current batch and waits for its result
+func (l *Loader) Load(ctx context.Context, request protocol.GQLRequest, into interface{}) error {
+	call := &loaderCall{
+		request: request,
+		done:    make(chan struct{}),
+	}
+
+	l.mu.Lock()
+	l.pending = append(l.pending, call)
+	if len(l.pending) >= l.MaxBatch {
+		l.flushLocked()
+	} else if l.timer == nil {
+		l.timer = time.AfterFunc(l.Wait, l.flush)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if call.err != nil {
+		return call.err
+	}
+	if err := json.Unmarshal(call.data, into); err != nil {
+		return fmt.Errorf("json data result unmarshal: %w", err)
+	}
+	return nil
+}
+
+func (l *Loader) flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.flushLocked()
+}
+
+func (l *Loader) flushLocked() {
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+	calls := l.pending
+	l.pending = nil
+	if len(calls) > 0 {
+		go l.send(calls)
+	}
+}
+
+// send sends the requests of the calls in a single batch and fans out the results
+func (l *Loader) send(calls []*loaderCall) {
+	defer func() {
+		for _, call := range calls {
+			close(call.done)
+		}
+	}()
+
+	payload := protocol.GQLBatchRequest{
+		Transaction: false,
+	}
+	for _, call := range calls {
+		payload.Batch = append(payload.Batch, call.request)
+	}
+
+	var result protocol.GQLBatchResponse
+	err := l.engine.Batch(l.ctx, payload, &result)
+	if err == nil && len(result.Errors) > 0 {
+		err = batchError(result.Errors[0])
+	}
+	if err == nil && len(result.Result) != len(calls) {
+		err = fmt.Errorf("expected %d batch results, got %d", len(calls), len(result.Result))
+	}
+	if err != nil {
+		for _, call := range calls {
+			call.err = err
+		}
+		return
+	}
+
+	for i, inner := range result.Result {
+		if len(inner.Errors) > 0 {
+			calls[i].err = batchError(inner.Errors[0])
+			continue
+		}
+		calls[i].data = inner.Data.Result
+	}
+}
@@ -0,0 +1,119 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/steebchen/prisma-client-go/engine/protocol"
+)
+
+// batchEngine answers batches with the records whose id is contained in a query, or null
+type batchEngine struct {
+	mu      sync.Mutex
+	batches [][]string
+	records map[string]string
+}
+
+func (e *batchEngine) Connect() error    { return nil }
+func (e *batchEngine) Disconnect() error { return nil }
+func (e *batchEngine) Name() string      { return "batch" }
+
+func (e *batchEngine) Do(ctx context.Context, payload interface{}, into interface{}) error {
+	panic("findUnique queries must be batched")
+}
+
+func (e *batchEngine) Batch(ctx context.Context, payload interface{}, into interface{}) error {
+	request := payload.(protocol.GQLBatchRequest)
+
+	var queries []string
+	var response protocol.GQLBatchResponse
+	for _, q := range request.Batch {
+		queries = append(queries, q.Query)
+		result := protocol.GQLResponse{Data: protocol.Data{Result: json.RawMessage("null")}}
+		for id, record := range e.records {
+			if strings.Contains(q.Query, `"`+id+`"`) {
+				result.Data.Result = json.RawMessage(record)
+			}
+		}
+		response.Result = append(response.Result, result)
+	}
+
+	e.mu.Lock()
+	e.batches = append(e.batches, queries)
+	e.mu.Unlock()
+
+	data, _ := json.Marshal(response)
+	return json.Unmarshal(data, into)
+}
+
+func TestLoader(t *testing.T) {
+	e := &batchEngine{
+		records: map[string]string{
+			"a": `{"id":"a"}`,
+			"b": `{"id":"b"}`,
+		},
+	}
+
+	loader := NewLoader(context.Background(), e)
+	ctx := WithLoader(context.Background(), loader)
+
+	ids := []string{"a", "b", "c"}
+	results := make([]json.RawMessage, len(ids))
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			q := NewQuery()
+			q.Engine = e
+			q.Operation = "query"
+			q.Method = "findUnique"
+			q.Model = "User"
+			q.Inputs = []Input{{
+				Name:   "where",
+				Fields: []Field{{Name: "id", Value: id}},
+			}}
+			q.Outputs = []Output{{Name: "id"}}
+			errs[i] = q.Exec(ctx, &results[i])
+		}(i, id)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+	assert.Equal(t, `{"id":"a"}`, string(results[0]))
+	assert.Equal(t, `{"id":"b"}`, string(results[1]))
+	assert.Equal(t, `null`, string(results[2]))
+	assert.Equal(t, 1, len(e.batches))
+	assert.Equal(t, 3, len(e.batches[0]))
+}
+
+func TestLoaderMaxBatch(t *testing.T) {
+	e := &batchEngine{}
+
+	loader := NewLoader(context.Background(), e)
+	loader.MaxBatch = 2
+	ctx := WithLoader(context.Background(), loader)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q := Query{Engine: e, Method: "findUnique", Model: "User"}
+			var v json.RawMessage
+			assert.NoError(t, q.Exec(ctx, &v))
+		}()
+	}
+	wg.Wait()
+
+	for _, batch := range e.batches {
+		assert.LessOrEqual(t, len(batch), 2)
+	}
+}
@@ -0,0 +1,99 @@
+package builder
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// QueryInfo describes a query which is executed by a client, see Middleware
+type QueryInfo struct {
+	// Model is the name of the model, and empty for raw queries and batches
+	Model string
+
+	// Method is the query engine method such as findMany or createOne, queryRaw for raw queries or batch for batches
+	Method string
+
+	// Operation is the PQL operation, which is either query or mutation
+	Operation string
+
+	// Query is the query to execute, and nil for batches. Middleware can modify it before calling next,
+	// e.g. to add inputs.
+	Query *Query
+
+	// Queries holds the queries of a batch, and is empty for single queries. Middleware can modify them before
+	// calling next.
+	Queries []*Query
+
+	// Transaction is true for batches which are executed in a transaction
+	Transaction bool
+
+	// Result is the value the result of a single query is decoded into, and nil for batches.
+	// Middleware which does not call next can fill it, e.g. from a cache.
+	Result interface{}
+
+	// Start is the time the execution of the query started, including all middleware
+	Start time.Time
+
+	// Duration is how long the query took to execute without middleware. It is set once next returns.
+	Duration time.Duration
+}
+
+// Middleware runs around every query executed by a client. It must call next to execute the query, and can
+// short-circuit by returning without calling next.
+type Middleware func(ctx context.Context, info *QueryInfo, next func(ctx context.Context) error) error
+
+// MiddlewareChain holds the middleware of a client. It is safe for concurrent use.
+type MiddlewareChain struct {
+	mu          sync.RWMutex
+	middlewares []Middleware
+}
+
+// Use adds middleware to the chain. Middleware added first runs outermost.
+func (c *MiddlewareChain) Use(middleware ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.middlewares = append(c.middlewares, middleware...)
+}
+
+// Run runs the middleware around exec
+func (c *MiddlewareChain) Run(ctx context.Context, info *QueryInfo, exec func(ctx context.Context) error) error {
+	var middlewares []Middleware
+	if c != nil {
+		c.mu.RLock()
+		middlewares = c.middlewares
+		c.mu.RUnlock()
+	}
+
+	if info.Start.IsZero() {
+		info.Start = time.Now()
+	}
+
+	var next func(i int) func(ctx context.Context) error
+	next = func(i int) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			if i < len(middlewares) {
+				return middlewares[i](ctx, info, next(i+1))
+			}
+			start := time.Now()
+			err := exec(ctx)
+			info.Duration = time.Since(start)
+			return err
+		}
+	}
+
+	return next(0)(ctx)
+}
+
+// MiddlewareEngine is implemented by engines which run middleware around queries, such as the generated client
+type MiddlewareEngine interface {
+	MiddlewareChain() *MiddlewareChain
+}
+
+// middlewareChain returns the middleware of the given engine, if any
+func middlewareChain(e interface{}) *MiddlewareChain {
+	if m, ok := e.(MiddlewareEngine); ok {
+		return m.MiddlewareChain()
+	}
+	return nil
+}
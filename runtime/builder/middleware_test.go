@@ -0,0 +1,53 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	var log []string
+
+	var chain MiddlewareChain
+	chain.Use(func(ctx context.Context, info *QueryInfo, next func(ctx context.Context) error) error {
+		log = append(log, "a before")
+		err := next(ctx)
+		log = append(log, "a after")
+		return err
+	}, func(ctx context.Context, info *QueryInfo, next func(ctx context.Context) error) error {
+		log = append(log, "b before")
+		info.Query.Method = "findFirst"
+		return next(ctx)
+	})
+
+	info := &QueryInfo{Query: &Query{Method: "findMany"}}
+	err := chain.Run(context.Background(), info, func(ctx context.Context) error {
+		log = append(log, "exec "+info.Query.Method)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a before", "b before", "exec findFirst", "a after"}, log)
+	assert.False(t, info.Start.IsZero())
+}
+
+func TestMiddlewareChainShortCircuit(t *testing.T) {
+	errDenied := errors.New("denied")
+
+	var chain MiddlewareChain
+	chain.Use(func(ctx context.Context, info *QueryInfo, next func(ctx context.Context) error) error {
+		return errDenied
+	})
+
+	executed := false
+	err := chain.Run(context.Background(), &QueryInfo{}, func(ctx context.Context) error {
+		executed = true
+		return nil
+	})
+
+	assert.Equal(t, errDenied, err)
+	assert.False(t, executed)
+}
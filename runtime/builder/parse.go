@@ -0,0 +1,221 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/steebchen/prisma-client-go/runtime/types"
+)
+
+// ParseWhere walks a dynamic filter, such as decoded JSON or the result of FilterFromQuery, and builds where params.
+// Keys are field names, and values are either a value to compare with, or a map of operations to values such as
+// {"contains": "a", "mode": "insensitive"}. field is called for every operation of a field. The keys AND, OR and
+// NOT accept a filter or a list of filters, which are parsed recursively and passed to combine. A filter with
+// several params is combined with AND first, so that it stays a single item of the list, e.g. the list of OR.
+func ParseWhere[T any](
+	filter map[string]interface{},
+	field func(name string, op string, value interface{}) (T, error),
+	combine func(op string, params []T) T,
+) ([]T, error) {
+	var params []T
+	for _, key := range sortedKeys(filter) {
+		value := filter[key]
+
+		switch key {
+		case "AND", "OR", "NOT":
+			filters, err := filterList(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			var inner []T
+			for _, f := range filters {
+				p, err := ParseWhere(f, field, combine)
+				if err != nil {
+					return nil, err
+				}
+				if len(p) > 1 {
+					p = []T{combine("AND", p)}
+				}
+				inner = append(inner, p...)
+			}
+			params = append(params, combine(key, inner))
+			continue
+		}
+
+		ops, ok := value.(map[string]interface{})
+		if !ok {
+			ops = map[string]interface{}{"equals": value}
+		}
+		for _, op := range sortedKeys(ops) {
+			p, err := field(key, op, ops[op])
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, p)
+		}
+	}
+	return params, nil
+}
+
+// OrderBy is a single field to sort by, see ParseOrderBy
+type OrderBy struct {
+	Field string
+	Desc  bool
+}
+
+// ParseOrderBy parses a comma separated list of fields to sort by, such as "-createdAt,name".
+// A leading "-" sorts in descending order, a leading "+" or none in ascending order.
+func ParseOrderBy(s string) []OrderBy {
+	var result []OrderBy
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		var desc bool
+		switch item[0] {
+		case '-':
+			desc = true
+			item = item[1:]
+		case '+':
+			item = item[1:]
+		}
+		result = append(result, OrderBy{
+			Field: item,
+			Desc:  desc,
+		})
+	}
+	return result
+}
+
+// FilterFromQuery builds a dynamic filter for ParseWhere from query parameters with brackets, such as
+// `filter[name][contains]=a&filter[age][gt]=18` for the key "filter". A parameter given multiple times results in
+// a list of values.
+func FilterFromQuery(query url.Values, key string) map[string]interface{} {
+	result := map[string]interface{}{}
+	for param, values := range query {
+		if !strings.HasPrefix(param, key+"[") || !strings.HasSuffix(param, "]") {
+			continue
+		}
+		path := strings.Split(param[len(key)+1:len(param)-1], "][")
+
+		var value interface{} = values[0]
+		if len(values) > 1 {
+			list := make([]interface{}, len(values))
+			for i, v := range values {
+				list[i] = v
+			}
+			value = list
+		}
+
+		current := result
+		for i, name := range path {
+			if i == len(path)-1 {
+				current[name] = value
+				break
+			}
+			next, ok := current[name].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				current[name] = next
+			}
+			current = next
+		}
+	}
+	return result
+}
+
+// Convert converts a dynamic value, such as a value decoded from JSON or a query parameter, to T.
+// Strings are additionally parsed as JSON values, so that "5" can be converted to an int and "true" to a bool.
+func Convert[T any](value interface{}) (T, error) {
+	if v, ok := value.(T); ok {
+		return v, nil
+	}
+
+	if value == nil {
+		var v T
+		return v, fmt.Errorf("%w: null is not a valid %T", types.ErrInvalidFilter, v)
+	}
+
+	if data, err := json.Marshal(value); err == nil {
+		var v T
+		if err := json.Unmarshal(data, &v); err == nil {
+			return v, nil
+		}
+	}
+
+	if s, ok := value.(string); ok {
+		var v T
+		if err := json.Unmarshal([]byte(s), &v); err == nil {
+			return v, nil
+		}
+	}
+
+	var v T
+	return v, fmt.Errorf("%w: %v is not a valid %T", types.ErrInvalidFilter, value, v)
+}
+
+// ConvertList converts a list of dynamic values to []T, see Convert. A string is split by commas, and any other
+// single value results in a list with one item.
+func ConvertList[T any](value interface{}) ([]T, error) {
+	var items []interface{}
+	switch v := value.(type) {
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			items = append(items, item)
+		}
+	default:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			for i := 0; i < rv.Len(); i++ {
+				items = append(items, rv.Index(i).Interface())
+			}
+		} else {
+			items = append(items, value)
+		}
+	}
+
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		v, err := Convert[T](item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// filterList returns a single filter or a list of filters as a list
+func filterList(value interface{}) ([]map[string]interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{v}, nil
+	case []map[string]interface{}:
+		return v, nil
+	case []interface{}:
+		var result []map[string]interface{}
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%w: expected a filter, got %T", types.ErrInvalidFilter, item)
+			}
+			result = append(result, m)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("%w: expected a filter or a list of filters, got %T", types.ErrInvalidFilter, value)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
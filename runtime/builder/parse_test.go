@@ -0,0 +1,109 @@
+package builder
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/steebchen/prisma-client-go/runtime/types"
+)
+
+func TestConvert(t *testing.T) {
+	i, err := Convert[int]("18")
+	assert.NoError(t, err)
+	assert.Equal(t, 18, i)
+
+	f, err := Convert[float64](float64(1.5))
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, f)
+
+	b, err := Convert[bool]("true")
+	assert.NoError(t, err)
+	assert.Equal(t, true, b)
+
+	d, err := Convert[time.Time]("2024-11-13T10:26:44Z")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 11, 13, 10, 26, 44, 0, time.UTC), d)
+
+	list, err := ConvertList[int]("1,2,3")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, list)
+
+	list, err = ConvertList[int]([]interface{}{float64(1), "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, list)
+
+	_, err = Convert[int]("abc")
+	assert.True(t, errors.Is(err, types.ErrInvalidFilter))
+
+	_, err = Convert[string](nil)
+	assert.True(t, errors.Is(err, types.ErrInvalidFilter))
+}
+
+func TestParseOrderBy(t *testing.T) {
+	assert.Equal(t, []OrderBy{
+		{Field: "createdAt", Desc: true},
+		{Field: "name"},
+		{Field: "age"},
+	}, ParseOrderBy("-createdAt, name,+age,"))
+}
+
+func TestFilterFromQuery(t *testing.T) {
+	query, err := url.ParseQuery("filter[name][contains]=a&filter[role]=USER&filter[id][in]=1&filter[id][in]=2&sort=-name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"name": map[string]interface{}{"contains": "a"},
+		"role": "USER",
+		"id":   map[string]interface{}{"in": []interface{}{"1", "2"}},
+	}, FilterFromQuery(query, "filter"))
+}
+
+func TestParseWhere(t *testing.T) {
+	field := func(name string, op string, value interface{}) (string, error) {
+		if name == "unknown" {
+			return "", types.ErrInvalidFilter
+		}
+		return name + "." + op, nil
+	}
+	combine := func(op string, params []string) string {
+		return op + "(" + strings.Join(params, ",") + ")"
+	}
+
+	actual, err := ParseWhere(map[string]interface{}{
+		"name": "a",
+		"age":  map[string]interface{}{"gt": 1, "lt": 5},
+		"OR":   []interface{}{map[string]interface{}{"a": 1}, map[string]interface{}{"b": 2}},
+	}, field, combine)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"OR(a.equals,b.equals)", "age.gt", "age.lt", "name.equals"}, actual)
+
+	// items with several params are combined with AND, so that they are not split into separate OR items
+	actual, err = ParseWhere(map[string]interface{}{
+		"OR": []interface{}{
+			map[string]interface{}{"name": "a", "age": 1},
+			map[string]interface{}{"email": "x"},
+			map[string]interface{}{"title": map[string]interface{}{"contains": "a", "mode": "insensitive"}},
+		},
+	}, field, combine)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"OR(AND(age.equals,name.equals),email.equals,AND(title.contains,title.mode))"}, actual)
+
+	actual, err = ParseWhere(map[string]interface{}{
+		"NOT": map[string]interface{}{"name": "a", "age": 1},
+	}, field, combine)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"NOT(AND(age.equals,name.equals))"}, actual)
+
+	_, err = ParseWhere(map[string]interface{}{"unknown": 1}, field, combine)
+	assert.True(t, errors.Is(err, types.ErrInvalidFilter))
+
+	_, err = ParseWhere(map[string]interface{}{"OR": "a"}, field, combine)
+	assert.True(t, errors.Is(err, types.ErrInvalidFilter))
+}
@@ -0,0 +1,120 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/steebchen/prisma-client-go/runtime/types"
+)
+
+// ExecInto executes the query with only the outputs needed for v, see SelectInto, and decodes the result into v.
+// It returns types.ErrNotFound if the query returned no record.
+func (q Query) ExecInto(ctx context.Context, v interface{}) error {
+	outputs, err := SelectInto(q.Outputs, v)
+	if err != nil {
+		return err
+	}
+	q.Outputs = outputs
+
+	var data json.RawMessage
+	if err := q.Exec(ctx, &data); err != nil {
+		return err
+	}
+
+	if len(data) == 0 || string(data) == "null" {
+		return types.ErrNotFound
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode result: %w", err)
+	}
+
+	return nil
+}
+
+// SelectInto returns the outputs which are needed to decode a query result into v, which must be a pointer to a
+// struct or to a slice of structs. Struct fields are matched to outputs by their json name, ignoring case in the
+// same way as encoding/json.
+// It returns an error if a field of v is not part of the given outputs, so that fields which were not fetched
+// can never be silently zero.
+//
+// The check runs at runtime using reflection, before the query is sent, and not at compile or generation time, as
+// the struct is defined by the caller. A struct which does not match the selection compiles fine and only fails
+// when the query is executed.
+func SelectInto(outputs []Output, v interface{}) ([]Output, error) {
+	names, err := jsonFields(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []Output
+	for _, name := range names {
+		found := false
+		for _, output := range outputs {
+			if strings.EqualFold(output.Name, name) {
+				selected = append(selected, output)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("field %q of %T is not selected", name, v)
+		}
+	}
+
+	return selected, nil
+}
+
+// jsonFields returns the json field names of the struct v points to
+func jsonFields(v interface{}) ([]string, error) {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("expected a pointer to a struct or to a slice of structs, got %T", v)
+	}
+	t = t.Elem()
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a pointer to a struct or to a slice of structs, got %T", v)
+	}
+
+	names := structFields(t)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%T has no fields to select", v)
+	}
+
+	return names, nil
+}
+
+// structFields returns the json field names of t, including the fields of embedded structs
+func structFields(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup("json")
+		tag, _, _ = strings.Cut(tag, ",")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			names = append(names, structFields(field.Type)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if hasTag && tag != "" {
+			name = tag
+		}
+		names = append(names, name)
+	}
+	return names
+}
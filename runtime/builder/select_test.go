@@ -0,0 +1,59 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectInto(t *testing.T) {
+	outputs := []Output{
+		{Name: "id"},
+		{Name: "email"},
+		{Name: "posts", Outputs: []Output{{Name: "id"}}},
+	}
+
+	type inner struct {
+		ID string `json:"id"`
+	}
+
+	tests := []struct {
+		name     string
+		v        interface{}
+		expected []Output
+		err      string
+	}{{
+		name:     "struct",
+		v:        &struct{ Email string }{},
+		expected: []Output{{Name: "email"}},
+	}, {
+		name: "slice with tags and embedded structs",
+		v: &[]struct {
+			inner
+			Posts  []inner `json:"posts,omitempty"`
+			Ignore string  `json:"-"`
+		}{},
+		expected: []Output{{Name: "id"}, {Name: "posts", Outputs: []Output{{Name: "id"}}}},
+	}, {
+		name: "unknown field",
+		v: &struct {
+			Name string `json:"name"`
+		}{},
+		err: `field "name" of *struct { Name string "json:\"name\"" } is not selected`,
+	}, {
+		name: "no pointer",
+		v:    inner{},
+		err:  "expected a pointer to a struct or to a slice of structs, got builder.inner",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := SelectInto(outputs, tt.v)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
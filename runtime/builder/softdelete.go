@@ -0,0 +1,47 @@
+package builder
+
+// ScopedInputs returns the inputs of the query including the filter which excludes soft deleted records, see
+// SoftDelete. The filter is not added if the where input already filters by the soft delete field, so that
+// deleted records can be queried explicitly.
+func (q Query) ScopedInputs() []Input {
+	if q.SoftDelete == "" {
+		return q.Inputs
+	}
+
+	var null *string
+	filter := Field{
+		Name: q.SoftDelete,
+		Fields: []Field{{
+			Name:  "equals",
+			Value: null,
+		}},
+	}
+
+	inputs := make([]Input, 0, len(q.Inputs)+1)
+	found := false
+	for _, input := range q.Inputs {
+		if input.Name == "where" && !found {
+			found = true
+			if !hasField(input.Fields, q.SoftDelete) {
+				input.Fields = append(append([]Field{}, input.Fields...), filter)
+			}
+		}
+		inputs = append(inputs, input)
+	}
+	if !found {
+		inputs = append(inputs, Input{
+			Name:   "where",
+			Fields: []Field{filter},
+		})
+	}
+	return inputs
+}
+
+func hasField(fields []Field, name string) bool {
+	for _, f := range fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
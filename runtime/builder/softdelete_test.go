@@ -0,0 +1,90 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopedInputs(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  Query
+		expect string
+	}{{
+		name: "no soft delete",
+		query: Query{
+			Inputs: []Input{{
+				Name:   "where",
+				Fields: []Field{{Name: "id", Value: "a"}},
+			}},
+		},
+		expect: `findManyUser(where:{id:"a",}) `,
+	}, {
+		name: "add to where",
+		query: Query{
+			SoftDelete: "deletedAt",
+			Inputs: []Input{{
+				Name:   "where",
+				Fields: []Field{{Name: "id", Value: "a"}},
+			}, {
+				Name:  "take",
+				Value: 1,
+			}},
+		},
+		expect: `findManyUser(where:{id:"a",deletedAt:{equals:null,},},take:1) `,
+	}, {
+		name: "add where",
+		query: Query{
+			SoftDelete: "deletedAt",
+		},
+		expect: `findManyUser(where:{deletedAt:{equals:null,},}) `,
+	}, {
+		name: "explicit filter",
+		query: Query{
+			SoftDelete: "deletedAt",
+			Inputs: []Input{{
+				Name: "where",
+				Fields: []Field{{
+					Name:   "deletedAt",
+					Fields: []Field{{Name: "not", Value: (*string)(nil)}},
+				}},
+			}},
+		},
+		expect: `findManyUser(where:{deletedAt:{not:null,},}) `,
+	}, {
+		name: "aggregate",
+		query: Query{
+			Method:     "aggregate",
+			SoftDelete: "deletedAt",
+			Outputs:    []Output{{Name: "_count", Outputs: []Output{{Name: "_all"}}}},
+		},
+		expect: `aggregateUser(where:{deletedAt:{equals:null,},}) {_count {_all }}`,
+	}, {
+		name: "group by",
+		query: Query{
+			Method:     "groupBy",
+			SoftDelete: "deletedAt",
+			Inputs: []Input{{
+				Name:  "by",
+				Value: []string{"name"},
+			}, {
+				Name:   "where",
+				Fields: []Field{{Name: "name", Value: "a"}},
+			}},
+			Outputs: []Output{{Name: "name"}},
+		},
+		expect: `groupByUser(by:["name"],where:{name:"a",deletedAt:{equals:null,},}) {name }`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.query.Method == "" {
+				tt.query.Method = "findMany"
+			}
+			tt.query.Model = "User"
+			actual, err := tt.query.BuildInner()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+}
@@ -0,0 +1,445 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/steebchen/prisma-client-go/runtime/types"
+)
+
+// ModelSchema describes the fields and relations of a model, which is needed to scope nested parts of a query
+type ModelSchema struct {
+	// Fields holds the names of the scalar fields
+	Fields []string
+
+	// Relations maps the names of relation fields to the related models
+	Relations map[string]Relation
+}
+
+// Relation describes a relation field of a model
+type Relation struct {
+	// Model is the name of the related model
+	Model string
+
+	// List is true for relations to many records
+	List bool
+}
+
+// HasField returns whether the model has a scalar field with the given name
+func (m ModelSchema) HasField(name string) bool {
+	for _, f := range m.Fields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantScope restricts all queries of a client to the tenant of the context of the query. It adds an equality
+// filter on the tenant field to every where input and sets the tenant field in every create input of models which
+// have the field, including nested relation filters, nested writes and fetched relations. Relations to a single
+// record of a model with the field can not be filtered, so fetching them fails with types.ErrUnscopedRelation.
+type TenantScope struct {
+	// Field is the name of the tenant field, such as tenantId
+	Field string
+
+	// Tenant returns the tenant of the context. Queries fail with types.ErrNoTenant if it returns false.
+	Tenant func(ctx context.Context) (string, bool)
+
+	// AllowRaw allows raw queries, which can not be scoped. They fail with types.ErrRawQueryNotAllowed otherwise.
+	AllowRaw bool
+
+	// Models holds the schema of all models by name
+	Models map[string]ModelSchema
+}
+
+// Middleware scopes single queries and all queries of batches and transactions, see TenantScope
+func (s *TenantScope) Middleware(ctx context.Context, info *QueryInfo, next func(ctx context.Context) error) error {
+	queries := info.Queries
+	if info.Query != nil {
+		queries = []*Query{info.Query}
+	}
+	for _, q := range queries {
+		if err := s.Apply(ctx, q); err != nil {
+			return err
+		}
+	}
+	return next(ctx)
+}
+
+// Apply scopes the query to the tenant of the context
+func (s *TenantScope) Apply(ctx context.Context, q *Query) error {
+	switch q.Method {
+	case "queryRaw", "executeRaw", "runCommandRaw", "findRaw", "aggregateRaw":
+		if !s.AllowRaw {
+			return fmt.Errorf("%w: %s", types.ErrRawQueryNotAllowed, q.Method)
+		}
+		return nil
+	}
+
+	tenant, ok := s.Tenant(ctx)
+	if !ok {
+		return types.ErrNoTenant
+	}
+
+	sc := scoper{TenantScope: s, tenant: tenant}
+
+	// resolve the soft delete filter first, as it checks the top-level fields of the where input
+	inputs := q.ScopedInputs()
+	q.SoftDelete = ""
+
+	scoped := make([]Input, 0, len(inputs)+1)
+	hasWhere := false
+	for _, input := range inputs {
+		var err error
+		switch input.Name {
+		case "where":
+			hasWhere = true
+			input.Fields, err = sc.where(q.Model, input.Fields, true)
+		case "data":
+			switch q.Method {
+			case "createOne":
+				input.Fields, err = sc.create(q.Model, input.Fields)
+			case "createMany", "createManyAndReturn":
+				input.Fields, err = sc.items(input.Fields, func(item []Field) ([]Field, error) {
+					return sc.create(q.Model, item)
+				})
+			default:
+				input.Fields, err = sc.update(q.Model, input.Fields)
+			}
+		case "create":
+			input.Fields, err = sc.create(q.Model, input.Fields)
+		case "update":
+			input.Fields, err = sc.update(q.Model, input.Fields)
+		}
+		if err != nil {
+			return err
+		}
+		scoped = append(scoped, input)
+	}
+
+	// queries such as findMany or deleteMany without any filter need a where input to be scoped
+	if !hasWhere && sc.scoped(q.Model) && needsWhere(q.Method) {
+		scoped = append(scoped, Input{
+			Name:   "where",
+			Fields: []Field{sc.filter()},
+		})
+	}
+	q.Inputs = scoped
+
+	outputs, err := sc.outputs(q.Model, q.Outputs)
+	if err != nil {
+		return err
+	}
+	q.Outputs = outputs
+	return nil
+}
+
+func needsWhere(method string) bool {
+	switch method {
+	case "findFirst", "findMany", "aggregate", "groupBy", "updateMany", "deleteMany":
+		return true
+	}
+	return false
+}
+
+type scoper struct {
+	*TenantScope
+	tenant string
+}
+
+func (s scoper) scoped(model string) bool {
+	return s.Models[model].HasField(s.Field)
+}
+
+func (s scoper) filter() Field {
+	return Field{
+		Name: s.Field,
+		Fields: []Field{{
+			Name:  "equals",
+			Value: s.tenant,
+		}},
+	}
+}
+
+// where scopes a where input of the given model. If top is false, only nested relation filters are scoped, which
+// is used for the items of AND, OR and NOT.
+func (s scoper) where(model string, fields []Field, top bool) ([]Field, error) {
+	schema := s.Models[model]
+	result := make([]Field, 0, len(fields)+1)
+	for _, f := range fields {
+		switch {
+		case f.Name == "AND" || f.Name == "OR" || f.Name == "NOT":
+			items, err := s.items(f.Fields, func(item []Field) ([]Field, error) {
+				return s.where(model, item, false)
+			})
+			if err != nil {
+				return nil, err
+			}
+			f.Fields = items
+		case schema.Relations[f.Name].Model != "":
+			related := schema.Relations[f.Name].Model
+			ops := make([]Field, 0, len(f.Fields))
+			for _, op := range f.Fields {
+				if op.Fields != nil {
+					inner, err := s.where(related, op.Fields, scopesRelationFilter(op.Name))
+					if err != nil {
+						return nil, err
+					}
+					op.Fields = inner
+				}
+				ops = append(ops, op)
+			}
+			f.Fields = ops
+		}
+		result = append(result, f)
+	}
+
+	if top && schema.HasField(s.Field) {
+		result = addFilter(result, s.filter())
+	}
+	return result, nil
+}
+
+// scopesRelationFilter returns whether the tenant filter is added to the given relation filter operation.
+// Adding it to some and is only matches related records of the tenant. every, none and isNot are negations, so
+// the filter would change their meaning: none {published} would become "no published record of the tenant", and
+// records with published related records of other tenants would still match. They are checked against all
+// related records instead, and only their nested relation filters are scoped.
+func scopesRelationFilter(op string) bool {
+	return op == "some" || op == "is"
+}
+
+// addFilter adds a filter to a where input. If the input already filters by the same field, it is added to AND
+// instead as an object of its own, so that the filters are not merged.
+func addFilter(fields []Field, filter Field) []Field {
+	if !hasField(fields, filter.Name) {
+		return append(fields, filter)
+	}
+
+	item := Field{
+		Fields: []Field{filter},
+	}
+
+	for i, f := range fields {
+		if f.Name == "AND" {
+			f.Fields = append(append([]Field{}, f.Fields...), item)
+			fields[i] = f
+			return fields
+		}
+	}
+
+	return append(fields, Field{
+		Name:     "AND",
+		List:     true,
+		WrapList: true,
+		Fields:   []Field{item},
+	})
+}
+
+// create scopes the data of a create input of the given model
+func (s scoper) create(model string, fields []Field) ([]Field, error) {
+	result, err := s.writes(model, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.scoped(model) {
+		return result, nil
+	}
+
+	for _, f := range result {
+		if f.Name == s.Field {
+			if !sameValue(f.Value, s.tenant) {
+				return nil, fmt.Errorf("%w: %s.%s", types.ErrTenantMismatch, model, s.Field)
+			}
+			return result, nil
+		}
+	}
+
+	return append(result, Field{
+		Name:  s.Field,
+		Value: s.tenant,
+	}), nil
+}
+
+// update scopes the data of an update input of the given model. The tenant field can not be updated.
+func (s scoper) update(model string, fields []Field) ([]Field, error) {
+	if s.scoped(model) && hasField(fields, s.Field) {
+		return nil, fmt.Errorf("%w: %s.%s can not be updated", types.ErrTenantMismatch, model, s.Field)
+	}
+	return s.writes(model, fields)
+}
+
+// writes scopes the nested writes of relation fields in a create or update input
+func (s scoper) writes(model string, fields []Field) ([]Field, error) {
+	schema := s.Models[model]
+	result := make([]Field, 0, len(fields)+1)
+	for _, f := range fields {
+		if related := schema.Relations[f.Name].Model; related != "" {
+			ops := make([]Field, 0, len(f.Fields))
+			for _, op := range f.Fields {
+				scoped, err := s.write(related, op)
+				if err != nil {
+					return nil, err
+				}
+				ops = append(ops, scoped)
+			}
+			f.Fields = ops
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+// write scopes a single nested write operation, such as create or connect, on the related model
+func (s scoper) write(model string, op Field) (Field, error) {
+	if op.Fields == nil {
+		return op, nil
+	}
+
+	var scope func(item []Field) ([]Field, error)
+	switch op.Name {
+	case "create":
+		scope = func(item []Field) ([]Field, error) {
+			return s.create(model, item)
+		}
+	case "createMany":
+		// createMany holds the records in its data field
+		fields, err := s.nested(model, op.Fields, map[string]func(string, []Field) ([]Field, error){
+			"data": func(model string, fields []Field) ([]Field, error) {
+				return s.items(fields, func(item []Field) ([]Field, error) {
+					return s.create(model, item)
+				})
+			},
+		})
+		op.Fields = fields
+		return op, err
+	case "connect", "disconnect", "set", "delete", "deleteMany":
+		scope = func(item []Field) ([]Field, error) {
+			return s.where(model, item, true)
+		}
+	case "connectOrCreate", "updateMany", "upsert":
+		scope = func(item []Field) ([]Field, error) {
+			return s.nested(model, item, map[string]func(string, []Field) ([]Field, error){
+				"where": func(model string, fields []Field) ([]Field, error) {
+					return s.where(model, fields, true)
+				},
+				"create": s.create,
+				"update": s.update,
+				"data":   s.update,
+			})
+		}
+	default:
+		return op, nil
+	}
+
+	var err error
+	if op.List || op.WrapList {
+		op.Fields, err = s.items(op.Fields, scope)
+		op.WrapList = false
+	} else {
+		op.Fields, err = scope(op.Fields)
+	}
+	return op, err
+}
+
+// nested scopes the named inputs of a nested write with the given functions
+func (s scoper) nested(model string, fields []Field, scopes map[string]func(string, []Field) ([]Field, error)) ([]Field, error) {
+	result := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		if scope, ok := scopes[f.Name]; ok && f.Fields != nil {
+			scoped, err := scope(model, f.Fields)
+			if err != nil {
+				return nil, err
+			}
+			f.Fields = scoped
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+// items scopes each item of a list. Unnamed fields are objects holding the fields of an item, and named fields are
+// items with a single field, which are converted to objects.
+func (s scoper) items(fields []Field, scope func(item []Field) ([]Field, error)) ([]Field, error) {
+	result := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		item := f.Fields
+		if f.Name != "" {
+			item = []Field{f}
+		}
+		scoped, err := scope(item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, Field{
+			Fields: scoped,
+		})
+	}
+	return result, nil
+}
+
+// outputs scopes the where inputs of fetched relations and relation counts. Relations to a single record can not
+// be filtered, so fetching one of a scoped model fails instead of returning a record of another tenant.
+func (s scoper) outputs(model string, outputs []Output) ([]Output, error) {
+	schema := s.Models[model]
+	result := make([]Output, 0, len(outputs))
+	for _, o := range outputs {
+		relation := schema.Relations[o.Name]
+		if o.Name == "_count" {
+			// relation counts are nested in _count and select relations of the same model
+			relation = Relation{Model: model}
+		}
+		if relation.Model == "" {
+			result = append(result, o)
+			continue
+		}
+
+		// only relations to many records can be filtered
+		if !relation.List && o.Name != "_count" && s.scoped(relation.Model) {
+			return nil, fmt.Errorf("%w: %s.%s", types.ErrUnscopedRelation, model, o.Name)
+		}
+		if relation.List {
+			inputs := make([]Input, 0, len(o.Inputs)+1)
+			hasWhere := false
+			for _, input := range o.Inputs {
+				if input.Name == "where" {
+					hasWhere = true
+					fields, err := s.where(relation.Model, input.Fields, true)
+					if err != nil {
+						return nil, err
+					}
+					input.Fields = fields
+				}
+				inputs = append(inputs, input)
+			}
+			if !hasWhere && s.scoped(relation.Model) {
+				inputs = append(inputs, Input{
+					Name:   "where",
+					Fields: []Field{s.filter()},
+				})
+			}
+			o.Inputs = inputs
+		}
+
+		nested, err := s.outputs(relation.Model, o.Outputs)
+		if err != nil {
+			return nil, err
+		}
+		o.Outputs = nested
+		result = append(result, o)
+	}
+	return result, nil
+}
+
+func sameValue(a, b interface{}) bool {
+	if v := reflect.ValueOf(a); v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false
+		}
+		a = v.Elem().Interface()
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,269 @@
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/steebchen/prisma-client-go/runtime/types"
+)
+
+type tenantKey struct{}
+
+func TestTenantScope(t *testing.T) {
+	scope := &TenantScope{
+		Field: "tenantId",
+		Tenant: func(ctx context.Context) (string, bool) {
+			tenant, ok := ctx.Value(tenantKey{}).(string)
+			return tenant, ok
+		},
+		Models: map[string]ModelSchema{
+			"User": {
+				Fields: []string{"id", "tenantId"},
+				Relations: map[string]Relation{
+					"posts": {Model: "Post", List: true},
+				},
+			},
+			"Post": {
+				Fields: []string{"id", "title", "tenantId"},
+				Relations: map[string]Relation{
+					"author": {Model: "User"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		query  Query
+		expect string
+		err    error
+	}{{
+		name: "find unique",
+		query: Query{
+			Method: "findUnique",
+			Model:  "User",
+			Inputs: []Input{{
+				Name:   "where",
+				Fields: []Field{{Name: "id", Fields: []Field{{Name: "equals", Value: "a"}}}},
+			}},
+			Outputs: []Output{{Name: "id"}, {
+				Name:    "posts",
+				Outputs: []Output{{Name: "id"}},
+			}},
+		},
+		expect: `findUniqueUser(where:{id:{equals:"a",},tenantId:{equals:"t",},}) {id posts (where:{tenantId:{equals:"t",},}){id }}`,
+	}, {
+		name: "find many without where",
+		query: Query{
+			Method:  "findMany",
+			Model:   "Post",
+			Outputs: []Output{{Name: "id"}},
+		},
+		expect: `findManyPost(where:{tenantId:{equals:"t",},}) {id }`,
+	}, {
+		name: "relation filters and explicit tenant",
+		query: Query{
+			Method: "findMany",
+			Model:  "User",
+			Inputs: []Input{{
+				Name: "where",
+				Fields: []Field{{
+					Name:     "OR",
+					List:     true,
+					WrapList: true,
+					Fields: []Field{{
+						Name: "posts",
+						Fields: []Field{{
+							Name:   "some",
+							Fields: []Field{{Name: "title", Value: "a"}},
+						}},
+					}},
+				}, {
+					Name:  "tenantId",
+					Value: "t",
+				}},
+			}},
+		},
+		expect: `findManyUser(where:{OR:[{posts:{some:{title:"a",tenantId:{equals:"t",},},},},],tenantId:"t",AND:[{tenantId:{equals:"t",},},],}) `,
+	}, {
+		name: "negated relation filters",
+		query: Query{
+			Method: "findMany",
+			Model:  "User",
+			Inputs: []Input{{
+				Name: "where",
+				Fields: []Field{{
+					Name: "posts",
+					Fields: []Field{{
+						Name:   "none",
+						Fields: []Field{{Name: "title", Value: "a"}},
+					}, {
+						Name: "every",
+						Fields: []Field{{
+							Name: "author",
+							Fields: []Field{{
+								Name:   "is",
+								Fields: []Field{{Name: "id", Value: "a"}},
+							}},
+						}},
+					}},
+				}},
+			}},
+		},
+		// none and every are checked against all related records, only the nested is filter is scoped
+		expect: `findManyUser(where:{posts:{none:{title:"a",},every:{author:{is:{id:"a",tenantId:{equals:"t",},},},},},tenantId:{equals:"t",},}) `,
+	}, {
+		name: "is not relation filter",
+		query: Query{
+			Method: "findMany",
+			Model:  "Post",
+			Inputs: []Input{{
+				Name: "where",
+				Fields: []Field{{
+					Name: "author",
+					Fields: []Field{{
+						Name:   "isNot",
+						Fields: []Field{{Name: "id", Value: "a"}},
+					}},
+				}},
+			}},
+		},
+		expect: `findManyPost(where:{author:{isNot:{id:"a",},},tenantId:{equals:"t",},}) `,
+	}, {
+		name: "delete unique",
+		query: Query{
+			Method: "deleteOne",
+			Model:  "User",
+			Inputs: []Input{{
+				Name:   "where",
+				Fields: []Field{{Name: "id", Value: "a"}},
+			}},
+			Outputs: []Output{{Name: "id"}},
+		},
+		expect: `deleteOneUser(where:{id:"a",tenantId:{equals:"t",},}) {id }`,
+	}, {
+		name: "upsert",
+		query: Query{
+			Method: "upsertOne",
+			Model:  "User",
+			Inputs: []Input{{
+				Name:   "where",
+				Fields: []Field{{Name: "id", Value: "a"}},
+			}, {
+				Name:   "create",
+				Fields: []Field{{Name: "id", Value: "a"}},
+			}, {
+				Name:   "update",
+				Fields: []Field{{Name: "id", Value: "b"}},
+			}},
+			Outputs: []Output{{Name: "id"}},
+		},
+		expect: `upsertOneUser(where:{id:"a",tenantId:{equals:"t",},},create:{id:"a",tenantId:"t",},update:{id:"b",}) {id }`,
+	}, {
+		name: "create with nested create",
+		query: Query{
+			Method: "createOne",
+			Model:  "User",
+			Inputs: []Input{{
+				Name: "data",
+				Fields: []Field{{Name: "id", Value: "a"}, {
+					Name: "posts",
+					Fields: []Field{{
+						Name: "create",
+						List: true,
+						Fields: []Field{{
+							Fields: []Field{{Name: "title", Value: "a"}},
+						}},
+					}},
+				}},
+			}},
+		},
+		expect: `createOneUser(data:{id:"a",posts:{create:[{title:"a",tenantId:"t",},],},tenantId:"t",}) `,
+	}, {
+		name: "create with another tenant",
+		query: Query{
+			Method: "createOne",
+			Model:  "User",
+			Inputs: []Input{{
+				Name:   "data",
+				Fields: []Field{{Name: "tenantId", Value: "other"}},
+			}},
+		},
+		err: types.ErrTenantMismatch,
+	}, {
+		name: "update tenant",
+		query: Query{
+			Method: "updateOne",
+			Model:  "User",
+			Inputs: []Input{{
+				Name:   "data",
+				Fields: []Field{{Name: "tenantId", Fields: []Field{{Name: "set", Value: "other"}}}},
+			}},
+		},
+		err: types.ErrTenantMismatch,
+	}, {
+		name: "fetch to-one relation",
+		query: Query{
+			Method: "findMany",
+			Model:  "Post",
+			Outputs: []Output{{Name: "id"}, {
+				Name:    "author",
+				Outputs: []Output{{Name: "id"}},
+			}},
+		},
+		err: types.ErrUnscopedRelation,
+	}, {
+		name: "fetch nested to-one relation",
+		query: Query{
+			Method: "findMany",
+			Model:  "User",
+			Outputs: []Output{{
+				Name: "posts",
+				Outputs: []Output{{
+					Name:    "author",
+					Outputs: []Output{{Name: "id"}},
+				}},
+			}},
+		},
+		err: types.ErrUnscopedRelation,
+	}, {
+		name: "relation count",
+		query: Query{
+			Method: "findMany",
+			Model:  "User",
+			Outputs: []Output{{
+				Name:    "_count",
+				Outputs: []Output{{Name: "posts"}},
+			}},
+		},
+		expect: `findManyUser(where:{tenantId:{equals:"t",},}) {_count {posts (where:{tenantId:{equals:"t",},})}}`,
+	}, {
+		name: "raw query",
+		query: Query{
+			Method: "queryRaw",
+		},
+		err: types.ErrRawQueryNotAllowed,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), tenantKey{}, "t")
+			err := scope.Apply(ctx, &tt.query)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+
+			actual, err := tt.query.BuildInner()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, actual)
+		})
+	}
+
+	t.Run("no tenant", func(t *testing.T) {
+		q := Query{Method: "findMany", Model: "User"}
+		assert.ErrorIs(t, scope.Apply(context.Background(), &q), types.ErrNoTenant)
+	})
+}
@@ -13,3 +13,31 @@ func TransformEquals(fields []Field) []Field {
 	}
 	return fields
 }
+
+// TransformAggregates merges aggregation fields such as `_sum { a }` and `_sum { b }` into a single output per
+// aggregation, keeping the order in which they were added
+func TransformAggregates(fields []Field) []Output {
+	var outputs []Output
+	for _, field := range fields {
+		index := -1
+		for i, output := range outputs {
+			if output.Name == field.Name {
+				index = i
+			}
+		}
+		if index == -1 {
+			outputs = append(outputs, Output{Name: field.Name})
+			index = len(outputs) - 1
+		}
+	inner:
+		for _, inner := range field.Fields {
+			for _, existing := range outputs[index].Outputs {
+				if existing.Name == inner.Name {
+					continue inner
+				}
+			}
+			outputs[index].Outputs = append(outputs[index].Outputs, Output{Name: inner.Name})
+		}
+	}
+	return outputs
+}
@@ -57,12 +57,24 @@ func (r TxExecuteResult) ExtractQuery() builder.Query {
 
 func (r TxExecuteResult) IsTx() {}
 
+// Result returns the result of the query after the transaction was executed.
+// It panics if the transaction was not executed or failed; use ResultErr to handle this case.
 func (r TxExecuteResult) Result() *types.BatchResult {
+	v, err := r.ResultErr()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ResultErr returns the result of the query after the transaction was executed, or transaction.ErrNoResult if the
+// transaction was not executed or failed.
+func (r TxExecuteResult) ResultErr() (*types.BatchResult, error) {
 	var v int
 	if err := r.result.Get(r.query.TxResult, &v); err != nil {
-		panic(err)
+		return nil, err
 	}
 	return &types.BatchResult{
 		Count: v,
-	}
+	}, nil
 }
@@ -0,0 +1,128 @@
+package raw
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// MarshalExtJSON converts a MongoDB document such as a filter, options or a pipeline stage to canonical Extended
+// JSON. It accepts anything the bson package can marshal to a document, e.g. bson.D, bson.M, maps and structs.
+// Strings and json.RawMessage values are expected to already contain Extended JSON and are passed through.
+func MarshalExtJSON(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "{}", nil
+	case string:
+		return v, nil
+	case json.RawMessage:
+		return string(v), nil
+	case []byte:
+		return string(v), nil
+	}
+
+	data, err := bson.MarshalExtJSON(value, true, false)
+	if err != nil {
+		return "", fmt.Errorf("marshal extended json: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// MarshalExtJSONList converts a list of MongoDB documents, such as the stages of an aggregation pipeline, to
+// canonical Extended JSON. It accepts any slice, e.g. []bson.D, bson.A or []interface{}.
+func MarshalExtJSONList(values interface{}) ([]string, error) {
+	result := []string{}
+	if values == nil {
+		return result, nil
+	}
+
+	v := reflect.ValueOf(values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a list of documents, got %T", values)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		item, err := MarshalExtJSON(v.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// UnmarshalExtJSON decodes a list of documents in Extended JSON into v, which is usually a pointer to a slice of
+// models or of a custom struct with json tags. ObjectIDs are decoded as hex strings, dates as time.Time and
+// Decimal128 values as decimal strings, so that they fit the Go types used in models.
+// If a document has no id field, it is set to the value of _id.
+func UnmarshalExtJSON(data []byte, v interface{}) error {
+	var docs []bson.M
+	if err := bson.UnmarshalExtJSON(data, false, &docs); err != nil {
+		return fmt.Errorf("unmarshal extended json: %w", err)
+	}
+
+	result := make([]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		item := normalize(doc).(map[string]interface{})
+		if _, ok := item["id"]; !ok {
+			if id, ok := item["_id"]; ok {
+				item["id"] = id
+			}
+		}
+		result = append(result, item)
+	}
+
+	o, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("marshal documents: %w", err)
+	}
+
+	if err := json.Unmarshal(o, v); err != nil {
+		return fmt.Errorf("unmarshal documents: %w", err)
+	}
+
+	return nil
+}
+
+// normalize converts BSON values to values which marshal to plain JSON
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case bson.M:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = normalize(item)
+		}
+		return m
+	case bson.D:
+		m := make(map[string]interface{}, len(v))
+		for _, e := range v {
+			m[e.Key] = normalize(e.Value)
+		}
+		return m
+	case bson.A:
+		list := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			list = append(list, normalize(item))
+		}
+		return list
+	case bson.ObjectID:
+		return v.Hex()
+	case bson.DateTime:
+		return v.Time().UTC()
+	case bson.Timestamp:
+		return time.Unix(int64(v.T), 0).UTC()
+	case bson.Decimal128:
+		return v.String()
+	case bson.Binary:
+		return v.Data
+	case bson.Null, bson.Undefined:
+		return nil
+	default:
+		return v
+	}
+}
@@ -0,0 +1,103 @@
+package raw
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestMarshalExtJSON(t *testing.T) {
+	id, err := bson.ObjectIDFromHex("67347ee4a18fa09750c1085a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{{
+		name:     "nil",
+		value:    nil,
+		expected: `{}`,
+	}, {
+		name:     "string",
+		value:    `{"email":"a"}`,
+		expected: `{"email":"a"}`,
+	}, {
+		name: "bson.D",
+		value: bson.D{
+			{Key: "_id", Value: id},
+			{Key: "age", Value: bson.M{"$gt": 5}},
+		},
+		expected: `{"_id":{"$oid":"67347ee4a18fa09750c1085a"},"age":{"$gt":{"$numberInt":"5"}}}`,
+	}, {
+		name:     "date",
+		value:    bson.M{"createdAt": time.Date(2024, 11, 13, 10, 26, 44, 0, time.UTC)},
+		expected: `{"createdAt":{"$date":{"$numberLong":"1731493604000"}}}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := MarshalExtJSON(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestMarshalExtJSONList(t *testing.T) {
+	actual, err := MarshalExtJSONList([]bson.D{
+		{{Key: "$match", Value: bson.M{"email": "a"}}},
+		{{Key: "$limit", Value: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{`{"$match":{"email":"a"}}`, `{"$limit":{"$numberInt":"1"}}`}, actual)
+
+	_, err = MarshalExtJSONList(bson.M{"$limit": 1})
+	assert.Error(t, err)
+}
+
+func TestUnmarshalExtJSON(t *testing.T) {
+	type item struct {
+		ID        string          `json:"id"`
+		CreatedAt time.Time       `json:"createdAt"`
+		Price     decimal.Decimal `json:"price"`
+		Count     int64           `json:"count"`
+		Tags      []string        `json:"tags"`
+		Nested    struct {
+			RefID string `json:"refId"`
+		} `json:"nested"`
+	}
+
+	data := []byte(`[{
+		"_id": {"$oid": "67347ee4a18fa09750c1085a"},
+		"createdAt": {"$date": "2024-11-13T10:26:44.246Z"},
+		"price": {"$numberDecimal": "1.50"},
+		"count": {"$numberLong": "5"},
+		"tags": ["a", "b"],
+		"nested": {"refId": {"$oid": "67348094597e341917026845"}}
+	}]`)
+
+	var actual []item
+	if err := UnmarshalExtJSON(data, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := item{
+		ID:        "67347ee4a18fa09750c1085a",
+		CreatedAt: time.Date(2024, 11, 13, 10, 26, 44, 246000000, time.UTC),
+		Price:     decimal.RequireFromString("1.50"),
+		Count:     5,
+		Tags:      []string{"a", "b"},
+	}
+	expected.Nested.RefID = "67348094597e341917026845"
+
+	assert.Equal(t, []item{expected}, actual)
+}
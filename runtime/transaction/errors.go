@@ -0,0 +1,68 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/steebchen/prisma-client-go/engine/protocol"
+	"github.com/steebchen/prisma-client-go/runtime/builder"
+	"github.com/steebchen/prisma-client-go/runtime/types"
+)
+
+// ErrNoResult is returned by the results of transaction queries when the transaction was not executed or failed
+var ErrNoResult = errors.New("no transaction result: the transaction was not executed or was rolled back")
+
+// TxError is returned when a query of a transaction fails and the transaction is rolled back.
+// It identifies the failed query and wraps the typed error of its UserFacingError, so that errors.As and helpers
+// such as IsErrUniqueConstraint work on it.
+type TxError struct {
+	// Index is the position of the failed query in the transaction, or -1 if the query engine did not report it
+	Index int
+	// Model is the model of the failed query
+	Model string
+	// Method is the method of the failed query, such as createOne
+	Method string
+	// Message is the error message of the query engine
+	Message string
+	// UserFacingError holds the error code and meta of the failure, if the query engine returned one
+	UserFacingError *protocol.UserFacingError
+}
+
+func (e *TxError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("transaction failed: %s", e.Message)
+	}
+	return fmt.Sprintf("transaction failed at query %d (%s.%s): %s", e.Index, e.Model, e.Method, e.Message)
+}
+
+func (e *TxError) Unwrap() error {
+	if e.UserFacingError == nil {
+		return nil
+	}
+	return types.FromUserFacingError(e.UserFacingError)
+}
+
+// newTxError creates a TxError for the query at index, or uses the batch index the query engine reports in the
+// user facing error if index is negative
+func newTxError(queries []builder.Query, index int, e protocol.GQLError) *TxError {
+	if index < 0 && e.UserFacingError != nil && e.UserFacingError.BatchRequestIdx != nil {
+		index = *e.UserFacingError.BatchRequestIdx
+	}
+	if index >= len(queries) {
+		index = -1
+	}
+
+	txErr := &TxError{
+		Index:           index,
+		Message:         e.RawMessage(),
+		UserFacingError: e.UserFacingError,
+	}
+	if e.UserFacingError != nil && e.UserFacingError.Message != "" {
+		txErr.Message = e.UserFacingError.Message
+	}
+	if index >= 0 {
+		txErr.Model = queries[index].Model
+		txErr.Method = queries[index].Method
+	}
+	return txErr
+}
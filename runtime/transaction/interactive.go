@@ -0,0 +1,115 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/engine"
+	"github.com/steebchen/prisma-client-go/logger"
+)
+
+const (
+	defaultMaxWait = 2 * time.Second
+	defaultTimeout = 5 * time.Second
+)
+
+// Option configures an interactive transaction
+type Option func(options *engine.TransactionOptions)
+
+// WithMaxWait sets the maximum time to wait for the transaction to start. Defaults to 2 seconds.
+func WithMaxWait(maxWait time.Duration) Option {
+	return func(options *engine.TransactionOptions) {
+		options.MaxWait = maxWait
+	}
+}
+
+// WithTimeout sets the maximum time the transaction can run before it is rolled back. Defaults to 5 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *engine.TransactionOptions) {
+		options.Timeout = timeout
+	}
+}
+
+// WithIsolationLevel sets the isolation level of the transaction.
+func WithIsolationLevel(level string) Option {
+	return func(options *engine.TransactionOptions) {
+		options.IsolationLevel = level
+	}
+}
+
+// Engine wraps an engine so that all queries are executed in the interactive transaction with the given ID.
+type Engine struct {
+	engine.Engine
+
+	// ID is the interactive transaction id
+	ID string
+}
+
+func (e Engine) Do(ctx context.Context, payload interface{}, into interface{}) error {
+	return e.Engine.Do(e.Context(ctx), payload, into)
+}
+
+func (e Engine) Batch(ctx context.Context, payload interface{}, into interface{}) error {
+	return e.Engine.Batch(e.Context(ctx), payload, into)
+}
+
+// Context returns a copy of ctx which carries the transaction, so that queries of any client sent with this
+// context join the transaction.
+func (e Engine) Context(ctx context.Context) context.Context {
+	return engine.WithTransactionID(ctx, e.ID)
+}
+
+// Interactive runs fn in an interactive transaction, which is committed if fn returns nil and rolled back if fn
+// returns an error or panics. fn receives an engine which sends all queries in the transaction.
+// If ctx or e already carry a transaction, fn joins it and the outer transaction decides whether to commit.
+func Interactive(ctx context.Context, e engine.Engine, fn func(tx Engine) error, options ...Option) (err error) {
+	if tx, ok := e.(Engine); ok {
+		return fn(tx)
+	}
+
+	if id, ok := engine.TransactionID(ctx); ok {
+		return fn(Engine{Engine: e, ID: id})
+	}
+
+	te, ok := e.(engine.TransactionEngine)
+	if !ok {
+		return fmt.Errorf("engine %s does not support interactive transactions", e.Name())
+	}
+
+	opts := engine.TransactionOptions{
+		MaxWait: defaultMaxWait,
+		Timeout: defaultTimeout,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	id, err := te.StartTransaction(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	// the rollback should happen even if ctx was cancelled
+	rollback := func() error {
+		return te.RollbackTransaction(context.WithoutCancel(ctx), id)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			if err := rollback(); err != nil {
+				logger.Debug.Printf("rollback after panic failed: %s", err)
+			}
+			panic(p)
+		}
+	}()
+
+	if err := fn(Engine{Engine: e, ID: id}); err != nil {
+		if rollbackErr := rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %s)", err, rollbackErr)
+		}
+		return err
+	}
+
+	return te.CommitTransaction(ctx, id)
+}
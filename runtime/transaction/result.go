@@ -8,18 +8,27 @@ import (
 	"github.com/steebchen/prisma-client-go/logger"
 )
 
+// Result caches the result of a query of a transaction
 type Result struct {
 	cache []byte
 }
 
+// Get decodes the result of the query into v. It returns ErrNoResult if the transaction was not executed or failed.
 func (r *Result) Get(c <-chan []byte, v interface{}) error {
 	var res []byte
 	if r.cache != nil {
 		res = r.cache
 	} else {
-		data, ok := <-c
-		if !ok {
-			return fmt.Errorf("result not fetched")
+		var data []byte
+		select {
+		case d, ok := <-c:
+			if !ok {
+				return ErrNoResult
+			}
+			data = d
+		default:
+			// the results are sent before the transaction returns, so there is nothing to wait for
+			return ErrNoResult
 		}
 		data, err := engine.TransformResponse(data)
 		if err != nil {
@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/steebchen/prisma-client-go/engine"
@@ -35,42 +36,45 @@ type Exec struct {
 }
 
 func (r Exec) Exec(ctx context.Context) error {
-	r.requests = make([]protocol.GQLRequest, len(r.queries))
+	queries := make([]builder.Query, len(r.queries))
 	for i, query := range r.queries {
-		str, err := query.ExtractQuery().Build()
-		if err != nil {
-			return err
-		}
-		r.requests[i] = protocol.GQLRequest{
-			Query:     str,
-			Variables: map[string]interface{}{},
-		}
+		queries[i] = query.ExtractQuery()
 	}
 
-	for _, q := range r.queries {
+	for _, q := range queries {
 		//goland:noinspection GoDeferInLoop
-		defer close(q.ExtractQuery().TxResult)
+		defer close(q.TxResult)
 	}
 
-	var result protocol.GQLBatchResponse
-	payload := protocol.GQLBatchRequest{
-		Batch:       r.requests,
-		Transaction: true,
-	}
-	if err := r.engine.Batch(ctx, payload, &result); err != nil {
-		return fmt.Errorf("could not send raw query: %w", err)
-	}
-	if len(result.Errors) > 0 {
-		first := result.Errors[0]
-		return fmt.Errorf("pql error: %s", first.RawMessage())
-	}
-	for i, inner := range result.Result {
-		if len(inner.Errors) > 0 {
-			first := result.Errors[0]
-			return fmt.Errorf("pql error: %s", first.RawMessage())
-		}
+	return builder.RunBatch(ctx, r.engine, queries, true, func(ctx context.Context, requests []protocol.GQLRequest) error {
+		r.requests = requests
 
-		r.queries[i].ExtractQuery().TxResult <- inner.Data.Result
-	}
-	return nil
+		var result protocol.GQLBatchResponse
+		payload := protocol.GQLBatchRequest{
+			Batch:       r.requests,
+			Transaction: true,
+		}
+		if err := r.engine.Batch(ctx, payload, &result); err != nil {
+			return fmt.Errorf("could not send raw query: %w", err)
+		}
+		if len(result.Errors) > 0 {
+			return newTxError(queries, -1, result.Errors[0])
+		}
+		if len(result.Result) != len(queries) {
+			return fmt.Errorf("expected %d transaction results, got %d", len(queries), len(result.Result))
+		}
+		// results are only passed on when all queries succeeded, as the transaction is rolled back otherwise
+		for i, inner := range result.Result {
+			if len(inner.Errors) > 0 {
+				return newTxError(queries, i, inner.Errors[0])
+			}
+		}
+		results := make([]json.RawMessage, len(result.Result))
+		for i, inner := range result.Result {
+			queries[i].TxResult <- inner.Data.Result
+			results[i] = inner.Data.Result
+		}
+		// the transaction is committed at this point, so failed hooks are reported as an AfterHookError
+		return builder.RunAfterHooks(ctx, queries, results)
+	})
 }
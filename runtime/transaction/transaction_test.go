@@ -0,0 +1,178 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/steebchen/prisma-client-go/engine/protocol"
+	"github.com/steebchen/prisma-client-go/runtime/builder"
+)
+
+type responseEngine struct {
+	response string
+}
+
+func (e *responseEngine) Connect() error    { return nil }
+func (e *responseEngine) Disconnect() error { return nil }
+func (e *responseEngine) Name() string      { return "response" }
+
+func (e *responseEngine) Do(ctx context.Context, payload interface{}, into interface{}) error {
+	return errors.New("not implemented")
+}
+
+func (e *responseEngine) Batch(ctx context.Context, payload interface{}, into interface{}) error {
+	return json.Unmarshal([]byte(e.response), into)
+}
+
+type txQuery struct {
+	query  builder.Query
+	result *Result
+}
+
+func (q txQuery) IsTx() {}
+
+func (q txQuery) ExtractQuery() builder.Query {
+	return q.query
+}
+
+func newTxQuery(e *responseEngine, method string) txQuery {
+	return txQuery{
+		query: builder.Query{
+			Engine:    e,
+			Operation: "mutation",
+			Method:    method,
+			Model:     "User",
+			TxResult:  make(chan []byte, 1),
+		},
+		result: &Result{},
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		err      *TxError
+	}{{
+		name:     "success",
+		response: `{"batchResult":[{"data":{"result":{"id":"a"}}},{"data":{"result":{"id":"b"}}}]}`,
+	}, {
+		name: "item error",
+		response: `{"batchResult":[
+			{"data":{"result":{"id":"a"}}},
+			{"errors":[{"error":"unique","user_facing_error":{"message":"unique","error_code":"P2002","meta":{"target":["email"]}}}]}
+		]}`,
+		err: &TxError{
+			Index:   1,
+			Model:   "User",
+			Method:  "updateOne",
+			Message: "unique",
+			UserFacingError: &protocol.UserFacingError{
+				Message:   "unique",
+				ErrorCode: "P2002",
+				Meta: protocol.Meta{
+					Target: []interface{}{"email"},
+					Values: map[string]interface{}{"target": []interface{}{"email"}},
+				},
+			},
+		},
+	}, {
+		name:     "batch error",
+		response: `{"errors":[{"error":"unique","user_facing_error":{"message":"unique","error_code":"P2002","batch_request_idx":0}}]}`,
+		err: &TxError{
+			Index:   0,
+			Model:   "User",
+			Method:  "createOne",
+			Message: "unique",
+			UserFacingError: &protocol.UserFacingError{
+				Message:         "unique",
+				ErrorCode:       "P2002",
+				BatchRequestIdx: new(int),
+			},
+		},
+	}, {
+		name:     "batch error without index",
+		response: `{"errors":[{"error":"timeout"}]}`,
+		err: &TxError{
+			Index:   -1,
+			Message: "timeout",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &responseEngine{response: tt.response}
+			a := newTxQuery(e, "createOne")
+			b := newTxQuery(e, "updateOne")
+
+			err := TX{Engine: e}.Transaction(a, b).Exec(context.Background())
+
+			var user struct {
+				ID string `json:"id"`
+			}
+			if tt.err == nil {
+				assert.NoError(t, err)
+				assert.NoError(t, a.result.Get(a.query.TxResult, &user))
+				assert.Equal(t, "a", user.ID)
+				assert.NoError(t, b.result.Get(b.query.TxResult, &user))
+				assert.Equal(t, "b", user.ID)
+				return
+			}
+
+			var txErr *TxError
+			assert.ErrorAs(t, err, &txErr)
+			assert.Equal(t, tt.err, txErr)
+
+			if tt.err.UserFacingError != nil {
+				var ufe *protocol.UserFacingError
+				assert.ErrorAs(t, err, &ufe)
+			}
+
+			// no results are passed on from a rolled back transaction
+			assert.ErrorIs(t, a.result.Get(a.query.TxResult, &user), ErrNoResult)
+			assert.ErrorIs(t, b.result.Get(b.query.TxResult, &user), ErrNoResult)
+		})
+	}
+}
+
+func TestResultNotExecuted(t *testing.T) {
+	q := newTxQuery(&responseEngine{}, "createOne")
+	var v interface{}
+	assert.ErrorIs(t, q.result.Get(q.query.TxResult, &v), ErrNoResult)
+}
+
+func TestTransactionAfterHooks(t *testing.T) {
+	errHook := errors.New("hook failed")
+
+	e := &responseEngine{response: `{"batchResult":[{"data":{"result":{"id":"a"}}},{"data":{"result":{"id":"b"}}}]}`}
+	a := newTxQuery(e, "createOne")
+	a.query.After = func(ctx context.Context, result []byte) error {
+		return errHook
+	}
+	b := newTxQuery(e, "createOne")
+	var after []string
+	b.query.After = func(ctx context.Context, result []byte) error {
+		after = append(after, string(result))
+		return nil
+	}
+
+	err := TX{Engine: e}.Transaction(a, b).Exec(context.Background())
+
+	// the transaction is committed, so the failed hook does not fail the transaction itself
+	var hookErr *builder.AfterHookError
+	assert.ErrorAs(t, err, &hookErr)
+	assert.ErrorIs(t, err, errHook)
+	var txErr *TxError
+	assert.False(t, errors.As(err, &txErr))
+
+	// the hooks of other queries still run, and all results are available
+	assert.Equal(t, []string{`{"id":"b"}`}, after)
+	var user struct {
+		ID string `json:"id"`
+	}
+	assert.NoError(t, a.result.Get(a.query.TxResult, &user))
+	assert.Equal(t, "a", user.ID)
+}
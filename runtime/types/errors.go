@@ -15,6 +15,25 @@ func IsErrNotFound(err error) bool {
 	return errors.Is(err, ErrNotFound)
 }
 
+// ErrInvalidFilter is returned when a dynamic filter or order contains an unknown field, an unknown operation or a
+// value which can not be converted to the type of the field
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// ErrNoTenant is returned when a client has a tenant scope, but the context of a query does not carry a tenant
+var ErrNoTenant = errors.New("no tenant in context")
+
+// ErrTenantMismatch is returned when a query of a client with a tenant scope writes a different tenant than the
+// one of the context, or updates the tenant field
+var ErrTenantMismatch = errors.New("tenant mismatch")
+
+// ErrRawQueryNotAllowed is returned for raw queries of a client with a tenant scope, as they can not be scoped,
+// unless raw queries are explicitly allowed
+var ErrRawQueryNotAllowed = errors.New("raw queries are not allowed with a tenant scope")
+
+// ErrUnscopedRelation is returned when a query of a client with a tenant scope fetches a relation to a single record
+// of a model with the tenant field, as such relations can not be filtered by the tenant
+var ErrUnscopedRelation = errors.New("relation can not be scoped to the tenant")
+
 type F interface {
 	~string
 }
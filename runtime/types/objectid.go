@@ -0,0 +1,108 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ObjectID is a MongoDB ObjectId in its 24 character hex representation, used for `@db.ObjectId` fields.
+// It is validated when it is sent to or received from the query engine, so invalid ids fail on the client.
+type ObjectID string
+
+// ErrInvalidObjectID is returned when a string is not a valid ObjectID
+var ErrInvalidObjectID = errors.New("invalid ObjectID")
+
+// NewObjectID generates a new ObjectID
+func NewObjectID() ObjectID {
+	return ObjectID(bson.NewObjectID().Hex())
+}
+
+// ParseObjectID validates the given hex string and returns it as an ObjectID
+func ParseObjectID(s string) (ObjectID, error) {
+	if _, err := bson.ObjectIDFromHex(s); err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidObjectID, s)
+	}
+	return ObjectID(s), nil
+}
+
+// IsValid returns whether the ObjectID is a valid 24 character hex string
+func (id ObjectID) IsValid() bool {
+	_, err := bson.ObjectIDFromHex(string(id))
+	return err == nil
+}
+
+// Timestamp returns the time at which the ObjectID was generated, or the zero time if it is invalid
+func (id ObjectID) Timestamp() time.Time {
+	oid, err := bson.ObjectIDFromHex(string(id))
+	if err != nil {
+		return time.Time{}
+	}
+	return oid.Timestamp()
+}
+
+func (id ObjectID) String() string {
+	return string(id)
+}
+
+// MarshalJSON validates the ObjectID and encodes it as a string. An empty ObjectID is encoded as an empty string,
+// so that zero values of models can still be marshalled.
+func (id ObjectID) MarshalJSON() ([]byte, error) {
+	if id != "" && !id.IsValid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidObjectID, string(id))
+	}
+	return json.Marshal(string(id))
+}
+
+// UnmarshalJSON accepts a hex string or an Extended JSON object such as {"$oid": "..."}
+func (id *ObjectID) UnmarshalJSON(data []byte) error {
+	if id == nil {
+		return errors.New("ObjectID: UnmarshalJSON on nil pointer")
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var ext struct {
+			OID string `json:"$oid"`
+		}
+		if err := json.Unmarshal(data, &ext); err != nil {
+			return fmt.Errorf("ObjectID: UnmarshalJSON error: %w", err)
+		}
+		s = ext.OID
+	}
+
+	if s == "" {
+		*id = ""
+		return nil
+	}
+
+	v, err := ParseObjectID(s)
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
+
+// MarshalBSONValue encodes the ObjectID as a BSON ObjectId, e.g. for filters of raw queries
+func (id ObjectID) MarshalBSONValue() (byte, []byte, error) {
+	oid, err := bson.ObjectIDFromHex(string(id))
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %q", ErrInvalidObjectID, string(id))
+	}
+	return byte(bson.TypeObjectID), oid[:], nil
+}
+
+// UnmarshalBSONValue decodes a BSON ObjectId
+func (id *ObjectID) UnmarshalBSONValue(t byte, data []byte) error {
+	if bson.Type(t) != bson.TypeObjectID || len(data) != 12 {
+		return fmt.Errorf("ObjectID: cannot decode BSON type %s", bson.Type(t))
+	}
+	var oid bson.ObjectID
+	copy(oid[:], data)
+	*id = ObjectID(oid.Hex())
+	return nil
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestObjectID(t *testing.T) {
+	id := NewObjectID()
+	assert.True(t, id.IsValid())
+	assert.WithinDuration(t, time.Now(), id.Timestamp(), time.Minute)
+
+	parsed, err := ParseObjectID(id.String())
+	assert.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	_, err = ParseObjectID("invalid")
+	assert.True(t, errors.Is(err, ErrInvalidObjectID))
+}
+
+func TestObjectID_JSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected ObjectID
+		wantErr  bool
+	}{{
+		name:     "hex",
+		input:    `"67347ee4a18fa09750c1085a"`,
+		expected: "67347ee4a18fa09750c1085a",
+	}, {
+		name:     "extended json",
+		input:    `{"$oid":"67347ee4a18fa09750c1085a"}`,
+		expected: "67347ee4a18fa09750c1085a",
+	}, {
+		name:     "empty",
+		input:    `""`,
+		expected: "",
+	}, {
+		name:    "invalid",
+		input:   `"abc"`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id ObjectID
+			err := json.Unmarshal([]byte(tt.input), &id)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidObjectID))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+
+	_, err := json.Marshal(ObjectID("abc"))
+	assert.True(t, errors.Is(err, ErrInvalidObjectID))
+}
+
+func TestObjectID_BSON(t *testing.T) {
+	id := ObjectID("67347ee4a18fa09750c1085a")
+
+	data, err := bson.MarshalExtJSON(bson.M{"_id": id}, true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"_id":{"$oid":"67347ee4a18fa09750c1085a"}}`, string(data))
+
+	var doc struct {
+		ID ObjectID `bson:"_id"`
+	}
+	assert.NoError(t, bson.UnmarshalExtJSON(data, true, &doc))
+	assert.Equal(t, id, doc.ID)
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor can not be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageArgs holds the arguments of relay-style connection pagination.
+// Either First (optionally with After) or Last (optionally with Before) must be set.
+type PageArgs struct {
+	// First is the number of records to return after the After cursor
+	First *int
+	// After is the cursor of the record after which records are returned
+	After *string
+	// Last is the number of records to return before the Before cursor
+	Last *int
+	// Before is the cursor of the record before which records are returned
+	Before *string
+	// TotalCount selects the total number of records matching the query
+	TotalCount bool
+}
+
+// PageInfo describes a page of relay-style connection pagination
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+// EncodeCursor encodes the given key values into an opaque cursor
+func EncodeCursor(values ...interface{}) string {
+	data, err := json.Marshal(values)
+	if err != nil {
+		panic(fmt.Errorf("encode cursor: %w", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes a cursor created by EncodeCursor into the given pointers, which must match the number and
+// types of the encoded values
+func DecodeCursor(cursor string, into ...interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+	}
+
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+	}
+
+	if len(values) != len(into) {
+		return fmt.Errorf("%w: expected %d values, got %d", ErrInvalidCursor, len(into), len(values))
+	}
+
+	for i, value := range values {
+		if err := json.Unmarshal(value, into[i]); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/engine/protocol"
+)
+
+// ErrValueTooLong is returned when a value is too long for the type of its column (P2000)
+type ErrValueTooLong struct {
+	*protocol.UserFacingError
+	// Column is the column of the value
+	Column string
+}
+
+func (e *ErrValueTooLong) Unwrap() error {
+	return e.UserFacingError
+}
+
+// ErrForeignKeyConstraint is returned when a foreign key constraint fails (P2003)
+type ErrForeignKeyConstraint struct {
+	*protocol.UserFacingError
+	// Field is the field or the name of the constraint, depending on the database
+	Field string
+}
+
+func (e *ErrForeignKeyConstraint) Unwrap() error {
+	return e.UserFacingError
+}
+
+// ErrNullConstraint is returned when a required field is set to null (P2011)
+type ErrNullConstraint struct {
+	*protocol.UserFacingError
+	// Fields holds the columns of the constraint, if the database reports them
+	Fields []string
+	// Key is the name of the constraint, if the database reports it
+	Key string
+}
+
+func (e *ErrNullConstraint) Unwrap() error {
+	return e.UserFacingError
+}
+
+// ErrRecordNotFound is returned when an operation depends on a record which does not exist, for example when
+// updating, deleting or connecting a record (P2025). It matches ErrNotFound with errors.Is.
+type ErrRecordNotFound struct {
+	*protocol.UserFacingError
+	// Cause describes which record was not found
+	Cause string
+	// Model is the model of the operation, if the query engine reports it
+	Model string
+}
+
+func (e *ErrRecordNotFound) Unwrap() error {
+	return e.UserFacingError
+}
+
+func (e *ErrRecordNotFound) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// ErrTransactionConflict is returned when a transaction fails due to a write conflict or a deadlock (P2034).
+// The transaction can be retried.
+type ErrTransactionConflict struct {
+	*protocol.UserFacingError
+}
+
+func (e *ErrTransactionConflict) Unwrap() error {
+	return e.UserFacingError
+}
+
+// ErrConnection is returned when the database server can not be reached (P1001) or operations time out (P1008)
+type ErrConnection struct {
+	*protocol.UserFacingError
+	// Host is the host of the database server (P1001)
+	Host string
+	// Port is the port of the database server (P1001)
+	Port string
+	// Time is the time after which the operations timed out (P1008)
+	Time string
+}
+
+func (e *ErrConnection) Unwrap() error {
+	return e.UserFacingError
+}
+
+// ErrPoolTimeout is returned when no connection could be fetched from the connection pool in time (P2024)
+type ErrPoolTimeout struct {
+	*protocol.UserFacingError
+	// ConnectionLimit is the size of the connection pool
+	ConnectionLimit int
+	// Timeout is the pool timeout
+	Timeout time.Duration
+}
+
+func (e *ErrPoolTimeout) Unwrap() error {
+	return e.UserFacingError
+}
+
+// FromUserFacingError returns the typed error for the code of a user facing error of the query engine, such as
+// ErrForeignKeyConstraint, which wraps the user facing error. Other codes return the user facing error itself.
+func FromUserFacingError(e *protocol.UserFacingError) error {
+	meta := e.Meta.Values
+	switch e.ErrorCode {
+	case "P2000":
+		return &ErrValueTooLong{
+			UserFacingError: e,
+			Column:          metaString(meta, "column_name"),
+		}
+	case "P2003":
+		return &ErrForeignKeyConstraint{
+			UserFacingError: e,
+			Field:           metaString(meta, "field_name"),
+		}
+	case "P2011":
+		fields, key := constraintTarget(meta["constraint"])
+		return &ErrNullConstraint{
+			UserFacingError: e,
+			Fields:          fields,
+			Key:             key,
+		}
+	case "P2025":
+		return &ErrRecordNotFound{
+			UserFacingError: e,
+			Cause:           metaString(meta, "cause"),
+			Model:           metaString(meta, "modelName"),
+		}
+	case "P2034":
+		return &ErrTransactionConflict{
+			UserFacingError: e,
+		}
+	case "P1001", "P1008":
+		return &ErrConnection{
+			UserFacingError: e,
+			Host:            metaString(meta, "database_host"),
+			Port:            metaString(meta, "database_port"),
+			Time:            metaString(meta, "time"),
+		}
+	case "P2024":
+		limit, _ := strconv.Atoi(metaString(meta, "connection_limit"))
+		timeout, _ := strconv.ParseFloat(metaString(meta, "timeout"), 64)
+		return &ErrPoolTimeout{
+			UserFacingError: e,
+			ConnectionLimit: limit,
+			Timeout:         time.Duration(timeout * float64(time.Second)),
+		}
+	}
+	return e
+}
+
+// ModelConstraints describes the columns and constraints of a model, which is used to map the targets of
+// constraint errors back to fields
+type ModelConstraints struct {
+	// Model is the name of the model
+	Model string
+	// Columns maps the database columns to the fields of the model
+	Columns map[string]string
+	// Constraints maps the default names of the constraints of the model to their fields
+	Constraints map[string][]string
+}
+
+// ConstraintFields returns the fields of the model which a constraint error refers to. This works for
+// unique constraints (P2002), foreign key constraints (P2003), null constraints (P2011) and values which are
+// too long (P2000). It returns false if err is not a constraint error or does not refer to the model.
+func ConstraintFields[T F](err error, m ModelConstraints) ([]T, bool) {
+	var ufe *protocol.UserFacingError
+	if !errors.As(err, &ufe) {
+		return nil, false
+	}
+
+	meta := ufe.Meta.Values
+	if model := metaString(meta, "modelName"); model != "" && model != m.Model {
+		return nil, false
+	}
+
+	var target interface{}
+	switch ufe.ErrorCode {
+	case "P2002":
+		target = ufe.Meta.Target
+	case "P2003":
+		target = meta["field_name"]
+	case "P2011":
+		target = meta["constraint"]
+	case "P2000":
+		target = meta["column_name"]
+	default:
+		return nil, false
+	}
+
+	columns, key := constraintTarget(target)
+	if key != "" {
+		// postgres reports foreign keys as "Post_authorId_fkey (index)"
+		key = strings.TrimSuffix(key, " (index)")
+		if fields, ok := m.Constraints[key]; ok {
+			result := make([]T, len(fields))
+			for i, f := range fields {
+				result[i] = T(f)
+			}
+			return result, true
+		}
+		columns = []string{key}
+	}
+	if len(columns) == 0 {
+		return nil, false
+	}
+
+	result := make([]T, 0, len(columns))
+	for _, column := range columns {
+		field, ok := m.Columns[column]
+		if !ok {
+			return nil, false
+		}
+		result = append(result, T(field))
+	}
+	return result, true
+}
+
+// constraintTarget decodes the target of a constraint, which is a list of columns or the name of the constraint
+func constraintTarget(target interface{}) (columns []string, key string) {
+	switch target := target.(type) {
+	case []interface{}:
+		for _, c := range target {
+			if column, ok := c.(string); ok {
+				columns = append(columns, column)
+			}
+		}
+	case string:
+		key = target
+	}
+	return columns, key
+}
+
+func metaString(meta map[string]interface{}, key string) string {
+	switch v := meta[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,183 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/steebchen/prisma-client-go/engine/protocol"
+)
+
+func userFacingError(t *testing.T, body string) error {
+	var e protocol.UserFacingError
+	if err := json.Unmarshal([]byte(body), &e); err != nil {
+		t.Fatal(err)
+	}
+	return fmt.Errorf("user facing error: %w", FromUserFacingError(&e))
+}
+
+func TestFromUserFacingError(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		check func(t *testing.T, err error)
+	}{{
+		name: "value too long",
+		body: `{"error_code":"P2000","message":"too long","meta":{"column_name":"name"}}`,
+		check: func(t *testing.T, err error) {
+			var e *ErrValueTooLong
+			assert.ErrorAs(t, err, &e)
+			assert.Equal(t, "name", e.Column)
+		},
+	}, {
+		name: "foreign key",
+		body: `{"error_code":"P2003","message":"fk","meta":{"field_name":"Post_authorId_fkey (index)"}}`,
+		check: func(t *testing.T, err error) {
+			var e *ErrForeignKeyConstraint
+			assert.ErrorAs(t, err, &e)
+			assert.Equal(t, "Post_authorId_fkey (index)", e.Field)
+		},
+	}, {
+		name: "null constraint",
+		body: `{"error_code":"P2011","message":"null","meta":{"constraint":["email"]}}`,
+		check: func(t *testing.T, err error) {
+			var e *ErrNullConstraint
+			assert.ErrorAs(t, err, &e)
+			assert.Equal(t, []string{"email"}, e.Fields)
+		},
+	}, {
+		name: "record not found",
+		body: `{"error_code":"P2025","message":"not found","meta":{"cause":"Record to update not found.","modelName":"User"}}`,
+		check: func(t *testing.T, err error) {
+			var e *ErrRecordNotFound
+			assert.ErrorAs(t, err, &e)
+			assert.Equal(t, "Record to update not found.", e.Cause)
+			assert.Equal(t, "User", e.Model)
+			assert.True(t, IsErrNotFound(err))
+		},
+	}, {
+		name: "transaction conflict",
+		body: `{"error_code":"P2034","message":"conflict"}`,
+		check: func(t *testing.T, err error) {
+			var e *ErrTransactionConflict
+			assert.ErrorAs(t, err, &e)
+			assert.Equal(t, "conflict", e.Error())
+		},
+	}, {
+		name: "unreachable",
+		body: `{"error_code":"P1001","message":"unreachable","meta":{"database_host":"localhost","database_port":5432}}`,
+		check: func(t *testing.T, err error) {
+			var e *ErrConnection
+			assert.ErrorAs(t, err, &e)
+			assert.Equal(t, "localhost", e.Host)
+			assert.Equal(t, "5432", e.Port)
+		},
+	}, {
+		name: "timeout",
+		body: `{"error_code":"P1008","message":"timeout","meta":{"time":"10s"}}`,
+		check: func(t *testing.T, err error) {
+			var e *ErrConnection
+			assert.ErrorAs(t, err, &e)
+			assert.Equal(t, "10s", e.Time)
+		},
+	}, {
+		name: "pool timeout",
+		body: `{"error_code":"P2024","message":"pool","meta":{"connection_limit":5,"timeout":10}}`,
+		check: func(t *testing.T, err error) {
+			var e *ErrPoolTimeout
+			assert.ErrorAs(t, err, &e)
+			assert.Equal(t, 5, e.ConnectionLimit)
+			assert.Equal(t, 10*time.Second, e.Timeout)
+		},
+	}, {
+		name: "unknown code",
+		body: `{"error_code":"P2999","message":"unknown"}`,
+		check: func(t *testing.T, err error) {
+			var e *protocol.UserFacingError
+			assert.ErrorAs(t, err, &e)
+			assert.Equal(t, "P2999", e.ErrorCode)
+			assert.False(t, IsErrNotFound(err))
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := userFacingError(t, tt.body)
+			tt.check(t, err)
+
+			// the user facing error is still available
+			var ufe *protocol.UserFacingError
+			assert.ErrorAs(t, err, &ufe)
+		})
+	}
+}
+
+func TestConstraintFields(t *testing.T) {
+	post := ModelConstraints{
+		Model: "Post",
+		Columns: map[string]string{
+			"id":        "id",
+			"title":     "title",
+			"author_id": "authorId",
+		},
+		Constraints: map[string][]string{
+			"Post_pkey":           {"id"},
+			"Post_title_key":      {"title"},
+			"Post_author_id_fkey": {"authorId"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		expected []string
+		ok       bool
+	}{{
+		name:     "unique columns",
+		body:     `{"error_code":"P2002","meta":{"target":["title"]}}`,
+		expected: []string{"title"},
+		ok:       true,
+	}, {
+		name:     "unique key",
+		body:     `{"error_code":"P2002","meta":{"target":"Post_title_key"}}`,
+		expected: []string{"title"},
+		ok:       true,
+	}, {
+		name:     "foreign key",
+		body:     `{"error_code":"P2003","meta":{"field_name":"Post_author_id_fkey (index)"}}`,
+		expected: []string{"authorId"},
+		ok:       true,
+	}, {
+		name:     "null constraint with mapped column",
+		body:     `{"error_code":"P2011","meta":{"constraint":["author_id"]}}`,
+		expected: []string{"authorId"},
+		ok:       true,
+	}, {
+		name:     "value too long",
+		body:     `{"error_code":"P2000","meta":{"column_name":"title"}}`,
+		expected: []string{"title"},
+		ok:       true,
+	}, {
+		name: "other model",
+		body: `{"error_code":"P2002","meta":{"target":"User_email_key"}}`,
+	}, {
+		name: "other model name",
+		body: `{"error_code":"P2002","meta":{"target":["title"],"modelName":"User"}}`,
+	}, {
+		name: "no constraint error",
+		body: `{"error_code":"P2025","meta":{"cause":"not found"}}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, ok := ConstraintFields[string](userFacingError(t, tt.body), post)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
+
+	_, ok := ConstraintFields[string](errors.New("other"), post)
+	assert.False(t, ok)
+}
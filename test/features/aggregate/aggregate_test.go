@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+// language=GraphQL
+var posts = []string{`
+	mutation {
+		result: createOnePost(data: {
+			id: "a",
+			category: "news",
+			views: 10,
+			rating: 4.5,
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "b",
+			category: "news",
+			views: 20,
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "c",
+			category: "blog",
+			views: 6,
+			rating: 3.5,
+		}) {
+			id
+		}
+	}
+`}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "count",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			count, err := client.Post.Count(
+				Post.Category.Equals("news"),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 2, count)
+		},
+	}, {
+		name: "count empty",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			count, err := client.Post.Count().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 0, count)
+		},
+	}, {
+		name:   "aggregate",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.Post.Aggregate(
+				Post.Views.Sum(),
+				Post.Views.Avg(),
+				Post.Views.Max(),
+				Post.Rating.Min(),
+				Post.Rating.Count(),
+			).Where(
+				Post.Views.Gte(5),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sum := 36
+			avg := 12.0
+			max := 20
+			min := 3.5
+
+			expected := &PostAggregateResult{
+				Count: PostCountAggregateResult{
+					All:    3,
+					Rating: 2,
+				},
+				Sum: PostSumAggregateResult{
+					Views: &sum,
+				},
+				Avg: PostAvgAggregateResult{
+					Views: &avg,
+				},
+				Max: PostMaxAggregateResult{
+					Views: &max,
+				},
+				Min: PostMinAggregateResult{
+					Rating: &min,
+				},
+			}
+
+			massert.Equal(t, expected, actual)
+		},
+	}, {
+		name:   "group by",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.Post.GroupBy(
+				Post.Category.Field(),
+			).Aggregate(
+				Post.Views.Sum(),
+			).Having(
+				Post.Views.Sum().Gt(10),
+			).OrderBy(
+				Post.Category.Order(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sum := 30
+
+			expected := []PostGroupByResult{{
+				InnerPost: InnerPost{
+					Category: "news",
+				},
+				PostAggregateResult: PostAggregateResult{
+					Count: PostCountAggregateResult{
+						All: 2,
+					},
+					Sum: PostSumAggregateResult{
+						Views: &sum,
+					},
+				},
+			}}
+
+			massert.Equal(t, expected, actual)
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, []test.Database{test.MySQL, test.PostgreSQL, test.SQLite}, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name: "independent results",
+		before: []string{`
+			mutation {
+				result: createOneUser(data: {
+					id: "a",
+					email: "a",
+					name: "a",
+				}) {
+					id
+				}
+			}
+		`},
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			results, err := client.Prisma.Batch(
+				client.User.FindMany(),
+				client.User.CreateOne(
+					User.Email.Set("a"),
+					User.Name.Set("duplicate"),
+				),
+				client.User.CreateOne(
+					User.Email.Set("b"),
+					User.Name.Set("b"),
+					User.ID.Set("b"),
+				),
+				client.User.FindUnique(User.ID.Equals("c")),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 4, len(results))
+
+			var users []UserModel
+			if err := results[0].Decode(&users); err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, len(users))
+
+			var user UserModel
+			if _, ok := IsErrUniqueConstraint(results[1].Decode(&user)); !ok {
+				t.Fatalf("expected a unique constraint error, got %v", results[1].Err)
+			}
+
+			if err := results[2].Decode(&user); err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, "b", user.ID)
+
+			if err := results[3].Decode(&user); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+
+			// the creation of b is not rolled back by the failing query
+			count, err := client.User.Count().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 2, count)
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
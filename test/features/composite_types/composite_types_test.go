@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+func str(v string) *string {
+	return &v
+}
+
+func TestCompositeTypes(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name: "create and find",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			created, err := client.Shop.CreateOne(
+				Shop.Name.Set("a"),
+				Shop.Address.Set(Address{
+					Street: "Main Street",
+					City:   str("Berlin"),
+					Geo:    &Geo{Lat: 1, Lng: 2},
+				}),
+				Shop.Locations.Set([]Geo{{Lat: 1, Lng: 2}, {Lat: 3, Lng: 4}}),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := InnerShop{
+				ID:   created.ID,
+				Name: "a",
+				Address: Address{
+					Street: "Main Street",
+					City:   str("Berlin"),
+					Geo:    &Geo{Lat: 1, Lng: 2},
+				},
+				Locations: []Geo{{Lat: 1, Lng: 2}, {Lat: 3, Lng: 4}},
+			}
+
+			massert.Equal(t, expected, created.InnerShop)
+
+			actual, err := client.Shop.FindMany(
+				Shop.Address.Is(
+					AddressFields.City.Equals("Berlin"),
+					AddressFields.Geo.Is(GeoFields.Lat.Gt(0)),
+				),
+				Shop.Billing.IsSet(false),
+				Shop.Locations.Some(GeoFields.Lng.Equals(4)),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []ShopModel{*created}, actual)
+
+			actual, err = client.Shop.FindMany(
+				Shop.Locations.Every(GeoFields.Lng.Gt(2)),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []ShopModel(nil), actual)
+		},
+	}, {
+		name: "update",
+		before: []string{`
+			mutation {
+				result: createOneShop(data: {
+					id: "5f9b1b9b9b9b9b9b9b9b9b9b",
+					name: "a",
+					address: { set: { street: "Main Street", city: "Berlin" } },
+					billing: { set: { street: "Billing Street" } },
+					locations: { set: [{ lat: 1, lng: 2 }, { lat: 3, lng: 4 }] },
+				}) {
+					id
+				}
+			}
+		`},
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			updated, err := client.Shop.FindUnique(
+				Shop.ID.Equals("5f9b1b9b9b9b9b9b9b9b9b9b"),
+			).Update(
+				Shop.Address.Update(
+					AddressFields.Street.Set("Second Street"),
+					AddressFields.City.Unset(),
+				),
+				Shop.Billing.Unset(),
+				Shop.Locations.Push(Geo{Lat: 5, Lng: 6}),
+				Shop.Locations.UpdateMany(
+					GeoFields.Lat.Gt(2),
+					GeoFields.Lng.Set(0),
+				),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := InnerShop{
+				ID:   "5f9b1b9b9b9b9b9b9b9b9b9b",
+				Name: "a",
+				Address: Address{
+					Street: "Second Street",
+				},
+				Locations: []Geo{{Lat: 1, Lng: 2}, {Lat: 3, Lng: 0}, {Lat: 5, Lng: 0}},
+			}
+
+			massert.Equal(t, expected, updated.InnerShop)
+		},
+	}}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, []test.Database{test.MongoDB}, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+func TestCreateMany(t *testing.T) {
+	t.Parallel()
+
+	str := "name"
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name: "create many",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			result, err := client.User.CreateMany(
+				[]UserSetParam{
+					User.ID.Set("a"),
+					User.Email.Set("a"),
+				},
+				[]UserSetParam{
+					User.ID.Set("b"),
+					User.Email.Set("b"),
+					User.Name.Set(str),
+				},
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, &BatchResult{Count: 2}, result)
+
+			actual, err := client.User.FindMany().OrderBy(
+				User.ID.Order(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := []UserModel{{
+				InnerUser: InnerUser{
+					ID:    "a",
+					Email: "a",
+				},
+			}, {
+				InnerUser: InnerUser{
+					ID:    "b",
+					Email: "b",
+					Name:  &str,
+				},
+			}}
+
+			massert.Equal(t, expected, actual)
+		},
+	}, {
+		name: "skip duplicates",
+		// language=GraphQL
+		before: []string{`
+			mutation {
+				result: createOneUser(data: {
+					id: "a",
+					email: "a",
+				}) {
+					id
+				}
+			}
+		`},
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			result, err := client.User.CreateMany(
+				[]UserSetParam{
+					User.ID.Set("a"),
+					User.Email.Set("a"),
+				},
+				[]UserSetParam{
+					User.ID.Set("b"),
+					User.Email.Set("b"),
+				},
+			).SkipDuplicates().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, &BatchResult{Count: 1}, result)
+		},
+	}, {
+		name: "return many",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.User.CreateMany(
+				[]UserSetParam{
+					User.ID.Set("a"),
+					User.Email.Set("a"),
+				},
+				[]UserSetParam{
+					User.ID.Set("b"),
+					User.Email.Set("b"),
+				},
+			).ReturnMany().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := []UserModel{{
+				InnerUser: InnerUser{
+					ID:    "a",
+					Email: "a",
+				},
+			}, {
+				InnerUser: InnerUser{
+					ID:    "b",
+					Email: "b",
+				},
+			}}
+
+			massert.Equal(t, expected, actual)
+		},
+	}, {
+		name: "transaction",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			createMany := client.User.CreateMany(
+				[]UserSetParam{
+					User.ID.Set("a"),
+					User.Email.Set("a"),
+				},
+			).Tx()
+
+			returnMany := client.User.CreateMany(
+				[]UserSetParam{
+					User.ID.Set("b"),
+					User.Email.Set("b"),
+				},
+			).ReturnMany().Tx()
+
+			if err := client.Prisma.Transaction(createMany, returnMany).Exec(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, &BatchResult{Count: 1}, createMany.Result())
+			massert.Equal(t, []UserModel{{
+				InnerUser: InnerUser{
+					ID:    "b",
+					Email: "b",
+				},
+			}}, returnMany.Result())
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, []test.Database{test.PostgreSQL}, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+// language=GraphQL
+var users = []string{`
+	mutation {
+		result: createOneUser(data: {
+			id: "a",
+			name: "alice",
+			posts: {
+				create: [
+					{ id: "p1", title: "hello" },
+					{ id: "p2", title: "hello" },
+					{ id: "p3", title: "world" },
+				],
+			},
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOneUser(data: {
+			id: "b",
+			name: "alice",
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOneUser(data: {
+			id: "c",
+			name: "bob",
+		}) {
+			id
+		}
+	}
+`}
+
+func TestDistinct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "find many",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.User.FindMany().Distinct(
+				User.Name.Field(),
+			).OrderBy(
+				User.ID.Order(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := []UserModel{{
+				InnerUser: InnerUser{
+					ID:   "a",
+					Name: "alice",
+				},
+			}, {
+				InnerUser: InnerUser{
+					ID:   "c",
+					Name: "bob",
+				},
+			}}
+
+			massert.Equal(t, expected, actual)
+		},
+	}, {
+		name:   "find first",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.User.FindFirst(
+				User.Name.Equals("alice"),
+			).Distinct(
+				User.Name.Field(),
+			).OrderBy(
+				User.ID.Order(SortOrderDesc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := &UserModel{
+				InnerUser: InnerUser{
+					ID:   "b",
+					Name: "alice",
+				},
+			}
+
+			massert.Equal(t, expected, actual)
+		},
+	}, {
+		name:   "with relation",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.User.FindUnique(
+				User.ID.Equals("a"),
+			).With(
+				User.Posts.Fetch().Distinct(Post.Title.Field()).OrderBy(Post.ID.Order(SortOrderAsc)),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := &UserModel{
+				InnerUser: InnerUser{
+					ID:   "a",
+					Name: "alice",
+				},
+				RelationsUser: RelationsUser{
+					Posts: []PostModel{{
+						InnerPost: InnerPost{
+							ID:       "p1",
+							Title:    "hello",
+							AuthorID: "a",
+						},
+					}, {
+						InnerPost: InnerPost{
+							ID:       "p3",
+							Title:    "world",
+							AuthorID: "a",
+						},
+					}},
+				},
+			}
+
+			massert.Equal(t, expected, actual)
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/steebchen/prisma-client-go/test"
@@ -135,6 +136,58 @@ func TestFields(t *testing.T) {
 			}
 
 			massert.Equal(t, expected, oneUser)
+
+			massert.Equal(t, true, oneUser.IsSelected(User.Keep.Field()))
+			massert.Equal(t, false, oneUser.IsSelected(User.Name.Field()))
+		},
+	}, {
+		name:   "exec into",
+		before: nil,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			_, err := client.User.CreateOne(
+				User.Name.Set("a"),
+				User.Keep.Set("keep"),
+				User.ID.Set("123"),
+				User.Age.Set(20),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			type userAge struct {
+				ID  string `json:"id"`
+				Age *int   `json:"age"`
+			}
+
+			var users []userAge
+			if err := client.User.FindMany().ExecInto(ctx, &users); err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []userAge{{ID: "123", Age: i(20)}}, users)
+
+			user, err := ExecInto[userAge](ctx, client.User.FindUnique(
+				User.ID.Equals("123"),
+			))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, userAge{ID: "123", Age: i(20)}, user)
+
+			_, err = ExecInto[userAge](ctx, client.User.FindUnique(
+				User.ID.Equals("123"),
+			).Select(
+				User.ID.Field(),
+			))
+			massert.Equal(t, `field "age" of *db.userAge is not selected`, err.Error())
+
+			_, err = ExecInto[userAge](ctx, client.User.FindUnique(
+				User.ID.Equals("456"),
+			))
+			if !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
 		},
 	}}
 
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+func TestHooks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name: "create, update and delete",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			var log []string
+			client.User.Hooks().BeforeCreate(func(ctx context.Context, params *[]UserSetParam) error {
+				*params = append(*params, User.ID.Set("a"))
+				return nil
+			}).AfterCreate(func(ctx context.Context, user *UserModel) error {
+				log = append(log, "created "+user.ID+" "+user.Name)
+				return nil
+			}).BeforeUpdate(func(ctx context.Context, params *[]UserSetParam) error {
+				*params = append(*params, User.Name.Set("updated"))
+				return nil
+			}).AfterUpdate(func(ctx context.Context, user *UserModel) error {
+				log = append(log, "updated "+user.Name)
+				return nil
+			}).BeforeDelete(func(ctx context.Context, where UserEqualsUniqueWhereParam) error {
+				user, err := client.User.FindUnique(where).Exec(ctx)
+				if err != nil {
+					return err
+				}
+				log = append(log, "deleting "+user.Name)
+				return nil
+			}).AfterDelete(func(ctx context.Context, user *UserModel) error {
+				log = append(log, "deleted "+user.ID)
+				return nil
+			})
+
+			created, err := client.User.CreateOne(User.Name.Set("x")).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, "a", created.ID)
+
+			if _, err := client.User.FindUnique(User.ID.Equals("a")).Update().Exec(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := client.User.FindUnique(User.ID.Equals("a")).Delete().Exec(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"created a x", "updated updated", "deleting updated", "deleted a"}, log)
+		},
+	}, {
+		name: "abort in before hook",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			errInvalid := errors.New("invalid")
+			client.User.Hooks().BeforeCreate(func(ctx context.Context, params *[]UserSetParam) error {
+				return errInvalid
+			})
+
+			_, err := client.User.CreateOne(User.Name.Set("x")).Exec(ctx)
+			if !errors.Is(err, errInvalid) {
+				t.Fatalf("expected errInvalid, got %v", err)
+			}
+
+			count, err := client.User.Count().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 0, count)
+		},
+	}, {
+		name: "transaction and upsert",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			var log []string
+			client.User.Hooks().BeforeCreate(func(ctx context.Context, params *[]UserSetParam) error {
+				*params = append(*params, User.Name.Set("hooked"))
+				return nil
+			}).AfterCreate(func(ctx context.Context, user *UserModel) error {
+				log = append(log, "created "+user.ID)
+				return nil
+			}).AfterUpsert(func(ctx context.Context, user *UserModel) error {
+				log = append(log, "upserted "+user.ID)
+				return nil
+			})
+
+			if err := client.Prisma.Transaction(
+				client.User.CreateOne(User.Name.Set("x"), User.ID.Set("a")).Tx(),
+			).Exec(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			upserted, err := client.User.UpsertOne(
+				User.ID.Equals("b"),
+			).Create(
+				User.Name.Set("y"),
+				User.ID.Set("b"),
+			).Update().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, "hooked", upserted.Name)
+			massert.Equal(t, []string{"created a", "upserted b"}, log)
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
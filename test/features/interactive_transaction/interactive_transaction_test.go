@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+// language=GraphQL
+var user = []string{`
+	mutation {
+		result: createOneUser(data: {
+			id: "a",
+			email: "john@example.com",
+			score: 10,
+		}) {
+			id
+		}
+	}
+`}
+
+func TestInteractiveTransaction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "commit",
+		before: user,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			err := client.Prisma.Tx(ctx, func(tx *PrismaClient) error {
+				user, err := tx.User.FindUnique(
+					User.ID.Equals("a"),
+				).Exec(ctx)
+				if err != nil {
+					return err
+				}
+
+				_, err = tx.User.CreateOne(
+					User.Email.Set("jane@example.com"),
+					User.ID.Set("b"),
+					User.Score.Set(user.Score*2),
+				).Exec(ctx)
+				return err
+			}, TxTimeout(10*time.Second))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := client.User.FindUnique(
+				User.ID.Equals("b"),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 20, actual.Score)
+		},
+	}, {
+		name:   "rollback",
+		before: user,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			err := client.Prisma.Tx(ctx, func(tx *PrismaClient) error {
+				_, err := tx.User.FindUnique(
+					User.ID.Equals("a"),
+				).Update(
+					User.Score.Set(0),
+				).Exec(ctx)
+				if err != nil {
+					return err
+				}
+
+				return fmt.Errorf("abort")
+			})
+			massert.Equal(t, "abort", err.Error())
+
+			actual, err := client.User.FindUnique(
+				User.ID.Equals("a"),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 10, actual.Score)
+		},
+	}, {
+		name:   "join via context",
+		before: user,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			update := func(ctx context.Context) error {
+				_, err := client.User.FindUnique(
+					User.ID.Equals("a"),
+				).Update(
+					User.Score.Set(0),
+				).Exec(ctx)
+				return err
+			}
+
+			err := client.Prisma.Tx(ctx, func(tx *PrismaClient) error {
+				if err := update(tx.Prisma.TxContext(ctx)); err != nil {
+					return err
+				}
+
+				return fmt.Errorf("abort")
+			})
+			massert.Equal(t, "abort", err.Error())
+
+			actual, err := client.User.FindUnique(
+				User.ID.Equals("a"),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 10, actual.Score)
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, []test.Database{test.MySQL, test.PostgreSQL, test.SQLite}, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
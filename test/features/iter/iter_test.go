@@ -0,0 +1,177 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+// language=GraphQL
+var posts = []string{`
+	mutation {
+		result: createOnePost(data: {
+			id: "a",
+			title: "1",
+			category: "x",
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "b",
+			title: "2",
+			category: "y",
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "c",
+			title: "3",
+			category: "x",
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "d",
+			title: "4",
+			category: "y",
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "e",
+			title: "5",
+			category: "x",
+		}) {
+			id
+		}
+	}
+`}
+
+func TestIter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "all records",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			it := client.Post.FindMany().Iter(ctx, 2)
+
+			var ids []string
+			for it.Next() {
+				ids = append(ids, it.Value().ID)
+			}
+			if err := it.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"a", "b", "c", "d", "e"}, ids)
+		},
+	}, {
+		name:   "where and order by",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			it := client.Post.FindMany(
+				Post.Category.Equals("x"),
+			).OrderBy(
+				Post.Title.Order(SortOrderDesc),
+			).Iter(ctx, 2)
+
+			var ids []string
+			for it.Next() {
+				ids = append(ids, it.Value().ID)
+			}
+			if err := it.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"e", "c", "a"}, ids)
+		},
+	}, {
+		name:   "by unique key",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			it := client.Post.FindMany(
+				Post.Category.Equals("y"),
+			).IterBy(ctx, 1, PostIterByTitle)
+
+			var titles []string
+			for it.Next() {
+				titles = append(titles, it.Value().Title)
+			}
+			if err := it.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"2", "4"}, titles)
+		},
+	}, {
+		name:   "select without key",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			it := client.Post.FindMany().Select(
+				Post.Title.Field(),
+			).Iter(ctx, 2)
+
+			var titles []string
+			for it.Next() {
+				titles = append(titles, it.Value().Title)
+			}
+			if err := it.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"1", "2", "3", "4", "5"}, titles)
+		},
+	}, {
+		name:   "cancelled context",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			ctx, cancel := context.WithCancel(ctx)
+
+			it := client.Post.FindMany().Iter(ctx, 2)
+
+			var ids []string
+			for it.Next() {
+				ids = append(ids, it.Value().ID)
+				if len(ids) == 2 {
+					cancel()
+				}
+			}
+
+			massert.Equal(t, []string{"a", "b"}, ids)
+			massert.Equal(t, context.Canceled, it.Err())
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
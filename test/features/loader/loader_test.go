@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+func TestLoader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name: "coalesce find unique",
+		before: []string{`
+			mutation {
+				result: createOneUser(data: {
+					id: "a",
+					name: "a",
+				}) {
+					id
+				}
+			}
+		`, `
+			mutation {
+				result: createOneUser(data: {
+					id: "b",
+					name: "b",
+				}) {
+					id
+				}
+			}
+		`},
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			ctx = client.Prisma.Loader(ctx)
+
+			ids := []string{"a", "b", "c"}
+			users := make([]*UserModel, len(ids))
+			errs := make([]error, len(ids))
+
+			var wg sync.WaitGroup
+			for i, id := range ids {
+				wg.Add(1)
+				go func(i int, id string) {
+					defer wg.Done()
+					users[i], errs[i] = client.User.FindUnique(User.ID.Equals(id)).Exec(ctx)
+				}(i, id)
+			}
+			wg.Wait()
+
+			massert.Equal(t, nil, errs[0])
+			massert.Equal(t, nil, errs[1])
+			massert.Equal(t, "a", users[0].Name)
+			massert.Equal(t, "b", users[1].Name)
+			if !errors.Is(errs[2], ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", errs[2])
+			}
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/runtime/builder"
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+// language=GraphQL
+var users = []string{`
+	mutation {
+		result: createOneUser(data: {
+			id: "a",
+			name: "a",
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOneUser(data: {
+			id: "b",
+			name: "b",
+		}) {
+			id
+		}
+	}
+`}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "log queries",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			var log []string
+			client.Prisma.Use(func(ctx context.Context, info *QueryInfo, next func(context.Context) error) error {
+				err := next(ctx)
+				if info.Duration <= 0 {
+					t.Errorf("expected a duration for %s", info.Method)
+				}
+				log = append(log, info.Model+" "+info.Method+" "+info.Operation)
+				return err
+			})
+
+			if _, err := client.User.FindMany().Exec(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := client.User.CreateOne(User.Name.Set("c")).Exec(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := client.Prisma.Transaction(
+				client.User.FindUnique(User.ID.Equals("a")).Update(User.Name.Set("x")).Tx(),
+			).Exec(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{
+				"User findMany query",
+				"User createOne mutation",
+				" batch mutation",
+			}, log)
+		},
+	}, {
+		name:   "modify query",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			client.Prisma.Use(func(ctx context.Context, info *QueryInfo, next func(context.Context) error) error {
+				if info.Method == "findMany" {
+					info.Query.Inputs = append(info.Query.Inputs, builder.Input{
+						Name:  "take",
+						Value: 1,
+					})
+				}
+				return next(ctx)
+			})
+
+			actual, err := client.User.FindMany().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 1, len(actual))
+		},
+	}, {
+		name:   "short-circuit",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			errDenied := errors.New("denied")
+			client.Prisma.Use(func(ctx context.Context, info *QueryInfo, next func(context.Context) error) error {
+				if info.Operation == "mutation" {
+					return errDenied
+				}
+				return next(ctx)
+			})
+
+			_, err := client.User.FindUnique(User.ID.Equals("a")).Delete().Exec(ctx)
+			if !errors.Is(err, errDenied) {
+				t.Fatalf("expected errDenied, got %v", err)
+			}
+
+			actual, err := client.User.FindMany().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 2, len(actual))
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
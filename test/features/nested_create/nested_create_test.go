@@ -0,0 +1,180 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+func TestNestedCreate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name: "create with nested create",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			_, err := client.User.CreateOne(
+				User.Email.Set("john@example.com"),
+				User.ID.Set("a"),
+				User.Posts.Create(
+					Post.Title.Set("first"),
+					Post.ID.Set("p1"),
+				),
+				User.Posts.Create(
+					Post.Title.Set("second"),
+					Post.ID.Set("p2"),
+				),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := client.Post.FindMany().OrderBy(
+				Post.ID.Order(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := []PostModel{{
+				InnerPost: InnerPost{
+					ID:       "p1",
+					Title:    "first",
+					AuthorID: "a",
+				},
+			}, {
+				InnerPost: InnerPost{
+					ID:       "p2",
+					Title:    "second",
+					AuthorID: "a",
+				},
+			}}
+
+			massert.Equal(t, expected, actual)
+		},
+	}, {
+		name: "create required relation",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			_, err := client.Post.CreateOne(
+				Post.Title.Set("first"),
+				Post.Author.Create(
+					User.Email.Set("john@example.com"),
+					User.ID.Set("a"),
+				),
+				Post.ID.Set("p1"),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := client.User.FindUnique(
+				User.Email.Equals("john@example.com"),
+			).With(
+				User.Posts.Fetch(),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, "a", actual.ID)
+			massert.Equal(t, 1, len(actual.Posts()))
+		},
+	}, {
+		name: "update with create many",
+		// language=GraphQL
+		before: []string{`
+			mutation {
+				result: createOneUser(data: {
+					id: "a",
+					email: "john@example.com",
+				}) {
+					id
+				}
+			}
+		`},
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			_, err := client.User.FindUnique(
+				User.ID.Equals("a"),
+			).Update(
+				User.Posts.CreateMany(
+					[]PostSetParam{Post.ID.Set("p1"), Post.Title.Set("first")},
+					[]PostSetParam{Post.ID.Set("p2"), Post.Title.Set("second")},
+				),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := client.Post.FindMany(
+				Post.AuthorID.Equals("a"),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 2, len(actual))
+		},
+	}, {
+		name: "connect or create",
+		// language=GraphQL
+		before: []string{`
+			mutation {
+				result: createOneUser(data: {
+					id: "a",
+					email: "john@example.com",
+				}) {
+					id
+				}
+			}
+		`},
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			// connects the existing user
+			first, err := client.Post.CreateOne(
+				Post.Title.Set("first"),
+				Post.Author.ConnectOrCreate(
+					User.Email.Equals("john@example.com"),
+					User.Email.Set("john@example.com"),
+				),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, "a", first.AuthorID)
+
+			// creates a new user
+			second, err := client.Post.CreateOne(
+				Post.Title.Set("second"),
+				Post.Author.ConnectOrCreate(
+					User.Email.Equals("jane@example.com"),
+					User.Email.Set("jane@example.com"),
+					User.ID.Set("b"),
+				),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, "b", second.AuthorID)
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+// language=GraphQL
+var user = []string{`
+	mutation {
+		result: createOneUser(data: {
+			id: "a",
+			email: "john@example.com",
+			posts: {
+				create: [
+					{ id: "p1", title: "first", published: false },
+					{ id: "p2", title: "second", published: false },
+					{ id: "p3", title: "draft", published: false },
+				],
+			},
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "p4",
+			title: "unlinked",
+		}) {
+			id
+		}
+	}
+`}
+
+func TestNestedUpdate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "update many and delete many",
+		before: user,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			_, err := client.User.FindUnique(
+				User.ID.Equals("a"),
+			).Update(
+				User.Posts.UpdateMany(
+					Post.Title.Not("draft"),
+					Post.Published.Set(true),
+				),
+				User.Posts.DeleteMany(
+					Post.Title.Equals("draft"),
+				),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := client.Post.FindMany(
+				Post.AuthorID.Equals("a"),
+			).OrderBy(
+				Post.ID.Order(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			author := "a"
+			expected := []PostModel{{
+				InnerPost: InnerPost{
+					ID:        "p1",
+					Title:     "first",
+					Published: true,
+					AuthorID:  &author,
+				},
+			}, {
+				InnerPost: InnerPost{
+					ID:        "p2",
+					Title:     "second",
+					Published: true,
+					AuthorID:  &author,
+				},
+			}}
+
+			massert.Equal(t, expected, actual)
+		},
+	}, {
+		name:   "upsert",
+		before: user,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			for _, bio := range []string{"created", "updated"} {
+				_, err := client.User.FindUnique(
+					User.ID.Equals("a"),
+				).Update(
+					User.Profile.Upsert().Create(
+						Profile.Bio.Set(bio),
+					).Update(
+						Profile.Bio.Set(bio),
+					),
+					User.Posts.Upsert(
+						Post.ID.Equals("p1"),
+					).Create(
+						Post.Title.Set(bio),
+					).Update(
+						Post.Title.Set(bio),
+					),
+				).Exec(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				profile, err := client.Profile.FindUnique(
+					Profile.UserID.Equals("a"),
+				).Exec(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				massert.Equal(t, bio, profile.Bio)
+
+				post, err := client.Post.FindUnique(
+					Post.ID.Equals("p1"),
+				).Exec(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				massert.Equal(t, bio, post.Title)
+			}
+		},
+	}, {
+		name:   "set to",
+		before: user,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			_, err := client.User.FindUnique(
+				User.ID.Equals("a"),
+			).Update(
+				User.Posts.SetTo(
+					Post.ID.Equals("p1"),
+					Post.ID.Equals("p4"),
+				),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := client.Post.FindMany(
+				Post.AuthorID.Equals("a"),
+			).OrderBy(
+				Post.ID.Order(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 2, len(actual))
+			massert.Equal(t, "p1", actual[0].ID)
+			massert.Equal(t, "p4", actual[1].ID)
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
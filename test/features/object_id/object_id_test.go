@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+const (
+	postA  ObjectID = "5f9b1b9b9b9b9b9b9b9b9b9a"
+	postB  ObjectID = "5f9b1b9b9b9b9b9b9b9b9b9b"
+	author ObjectID = "5f9b1b9b9b9b9b9b9b9b9b9c"
+)
+
+// language=GraphQL
+var posts = []string{`
+	mutation {
+		result: createOnePost(data: {
+			id: "5f9b1b9b9b9b9b9b9b9b9b9a",
+			title: "a",
+			authorId: "5f9b1b9b9b9b9b9b9b9b9b9c",
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "5f9b1b9b9b9b9b9b9b9b9b9b",
+			title: "b",
+			authorId: "5f9b1b9b9b9b9b9b9b9b9b9d",
+		}) {
+			id
+		}
+	}
+`}
+
+func TestObjectID(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "equals and in",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			post, err := client.Post.FindUnique(
+				Post.ID.Equals(postA),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, postA, post.ID)
+			massert.Equal(t, author, post.AuthorID)
+
+			actual, err := client.Post.FindMany(
+				Post.ID.In([]ObjectID{postA, postB}),
+			).OrderBy(
+				Post.Title.Order(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 2, len(actual))
+			massert.Equal(t, postB, actual[1].ID)
+
+			actual, err = client.Post.FindMany(
+				Post.AuthorID.Equals(author),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, len(actual))
+			massert.Equal(t, postA, actual[0].ID)
+		},
+	}, {
+		name:   "set",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			reader := NewObjectID()
+
+			created, err := client.Post.CreateOne(
+				Post.Title.Set("c"),
+				Post.AuthorID.Set(author),
+				Post.ReaderIds.Set([]ObjectID{reader}),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !created.ID.IsValid() {
+				t.Fatalf("expected a valid id, got %q", created.ID)
+			}
+
+			updated, err := client.Post.FindUnique(
+				Post.ID.Equals(created.ID),
+			).Update(
+				Post.AuthorID.Set(postB),
+				Post.ReaderIds.Push([]ObjectID{postA}),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, postB, updated.AuthorID)
+			massert.Equal(t, []ObjectID{reader, postA}, updated.ReaderIds)
+
+			actual, err := client.Post.FindMany(
+				Post.ReaderIds.Has(reader),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, len(actual))
+			massert.Equal(t, created.ID, actual[0].ID)
+		},
+	}, {
+		name:   "find raw",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.Post.FindRaw(bson.M{
+				"authorId": author,
+			}).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, len(actual))
+			massert.Equal(t, postA, actual[0].ID)
+			massert.Equal(t, author, actual[0].AuthorID)
+		},
+	}, {
+		name:   "invalid id",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			_, err := client.Post.FindUnique(
+				Post.ID.Equals("invalid"),
+			).Exec(ctx)
+			if !errors.Is(err, ErrInvalidObjectID) {
+				t.Fatalf("expected ErrInvalidObjectID, got %v", err)
+			}
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, []test.Database{test.MongoDB}, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+// language=GraphQL
+var users = []string{`
+	mutation {
+		result: createOneUser(data: {
+			id: "a",
+			name: "bob",
+			posts: {
+				create: [
+					{ id: "p1", title: "a" },
+				],
+			},
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOneUser(data: {
+			id: "b",
+			name: "alice",
+			posts: {
+				create: [
+					{ id: "p2", title: "b" },
+					{ id: "p3", title: "c" },
+				],
+			},
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOneUser(data: {
+			id: "c",
+		}) {
+			id
+		}
+	}
+`}
+
+func TestOrderRelations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "order by relation field",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.Post.FindMany().OrderBy(
+				Post.Author.Order(User.Name.Order(SortOrderAsc)),
+				Post.ID.Order(SortOrderDesc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []string
+			for _, post := range actual {
+				ids = append(ids, post.ID)
+			}
+
+			massert.Equal(t, []string{"p3", "p2", "p1"}, ids)
+		},
+	}, {
+		name:   "order by relation count",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.User.FindMany().OrderBy(
+				User.Posts.OrderByCount(SortOrderDesc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []string
+			for _, user := range actual {
+				ids = append(ids, user.ID)
+			}
+
+			massert.Equal(t, []string{"b", "a", "c"}, ids)
+		},
+	}, {
+		name:   "order nulls",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			first, err := client.User.FindMany().OrderBy(
+				User.Name.OrderNullsFirst(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, "c", first[0].ID)
+
+			last, err := client.User.FindMany().OrderBy(
+				User.Name.OrderNullsLast(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, "c", last[2].ID)
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, []test.Database{test.MySQL, test.PostgreSQL, test.SQLite}, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
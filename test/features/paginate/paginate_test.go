@@ -0,0 +1,226 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+// language=GraphQL
+var posts = []string{`
+	mutation {
+		result: createOnePost(data: {
+			id: "a",
+			title: "a",
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "b",
+			title: "b",
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "c",
+			title: "c",
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "d",
+			title: "d",
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "e",
+			title: "e",
+		}) {
+			id
+		}
+	}
+`}
+
+// language=GraphQL
+var memberships = []string{`
+	mutation {
+		result: createOneMembership(data: {
+			orgID: "o1",
+			userID: "a",
+			role: "member",
+		}) {
+			orgID
+		}
+	}
+`, `
+	mutation {
+		result: createOneMembership(data: {
+			orgID: "o1",
+			userID: "b",
+			role: "member",
+		}) {
+			orgID
+		}
+	}
+`, `
+	mutation {
+		result: createOneMembership(data: {
+			orgID: "o2",
+			userID: "a",
+			role: "member",
+		}) {
+			orgID
+		}
+	}
+`}
+
+func postIDs(conn *PostConnection) []string {
+	var ids []string
+	for _, edge := range conn.Edges {
+		ids = append(ids, edge.Node.ID)
+	}
+	return ids
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	two := 2
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "forward",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			first, err := client.Post.FindMany().Paginate(ctx, PageArgs{
+				First:      &two,
+				TotalCount: true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"a", "b"}, postIDs(first))
+			massert.Equal(t, true, first.PageInfo.HasNextPage)
+			massert.Equal(t, false, first.PageInfo.HasPreviousPage)
+			massert.Equal(t, 5, *first.TotalCount)
+
+			second, err := client.Post.FindMany().Paginate(ctx, PageArgs{
+				First: &two,
+				After: first.PageInfo.EndCursor,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"c", "d"}, postIDs(second))
+			massert.Equal(t, true, second.PageInfo.HasNextPage)
+			massert.Equal(t, true, second.PageInfo.HasPreviousPage)
+
+			third, err := client.Post.FindMany().Paginate(ctx, PageArgs{
+				First: &two,
+				After: second.PageInfo.EndCursor,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"e"}, postIDs(third))
+			massert.Equal(t, false, third.PageInfo.HasNextPage)
+		},
+	}, {
+		name:   "backward",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			last, err := client.Post.FindMany().Paginate(ctx, PageArgs{
+				Last: &two,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"d", "e"}, postIDs(last))
+			massert.Equal(t, true, last.PageInfo.HasPreviousPage)
+
+			previous, err := client.Post.FindMany().Paginate(ctx, PageArgs{
+				Last:   &two,
+				Before: last.PageInfo.StartCursor,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"b", "c"}, postIDs(previous))
+			massert.Equal(t, true, previous.PageInfo.HasPreviousPage)
+			massert.Equal(t, true, previous.PageInfo.HasNextPage)
+		},
+	}, {
+		name:   "composite key",
+		before: memberships,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			first, err := client.Membership.FindMany().Paginate(ctx, PageArgs{
+				First: &two,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 2, len(first.Edges))
+
+			second, err := client.Membership.FindMany().Paginate(ctx, PageArgs{
+				First: &two,
+				After: first.PageInfo.EndCursor,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 1, len(second.Edges))
+			massert.Equal(t, "o2", second.Edges[0].Node.OrgID)
+			massert.Equal(t, "a", second.Edges[0].Node.UserID)
+		},
+	}, {
+		name: "invalid cursor",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			cursor := "invalid"
+			_, err := client.Post.FindMany().Paginate(ctx, PageArgs{
+				First: &two,
+				After: &cursor,
+			})
+			massert.Equal(t, true, errors.Is(err, ErrInvalidCursor))
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, []test.Database{test.MySQL, test.PostgreSQL, test.SQLite}, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
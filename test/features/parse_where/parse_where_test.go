@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+// language=GraphQL
+var users = []string{`
+	mutation {
+		result: createOneUser(data: {
+			id: "a",
+			email: "a@example.com",
+			name: "Alice",
+			age: 20,
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOneUser(data: {
+			id: "b",
+			email: "b@example.com",
+			age: 30,
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOneUser(data: {
+			id: "c",
+			email: "c@test.com",
+			name: "Carol",
+			age: 40,
+		}) {
+			id
+		}
+	}
+`}
+
+func ids(items []UserModel) []string {
+	var result []string
+	for _, item := range items {
+		result = append(result, item.ID)
+	}
+	return result
+}
+
+func TestParseWhere(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "map filter",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			params, err := ParseUserWhere(map[string]interface{}{
+				"email": map[string]interface{}{"endsWith": "@example.com"},
+				"OR": []interface{}{
+					map[string]interface{}{"name": nil},
+					map[string]interface{}{"age": map[string]interface{}{"lt": 25}},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := client.User.FindMany(params...).OrderBy(
+				User.ID.Order(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"a", "b"}, ids(actual))
+		},
+	}, {
+		name:   "or with several fields in an item",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			// (name = Alice AND age = 30) OR email starts with c, which does not match Alice as she is 20
+			params, err := ParseUserWhere(map[string]interface{}{
+				"OR": []interface{}{
+					map[string]interface{}{"name": "Alice", "age": 30},
+					map[string]interface{}{"email": map[string]interface{}{"startsWith": "c"}},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := client.User.FindMany(params...).OrderBy(
+				User.ID.Order(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"c"}, ids(actual))
+		},
+	}, {
+		name:   "query parameters",
+		before: users,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			query, err := url.ParseQuery("filter[age][gte]=30&filter[id][in]=a,b,c&sort=-age")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			params, err := ParseUserWhere(FilterFromQuery(query, "filter"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			orderBy, err := UserOrderByFromString(query.Get("sort"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := client.User.FindMany(params...).OrderBy(orderBy...).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []string{"c", "b"}, ids(actual))
+		},
+	}, {
+		name: "invalid filters",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			invalid := []map[string]interface{}{
+				{"unknown": "a"},
+				{"age": map[string]interface{}{"contains": "a"}},
+				{"age": "abc"},
+				{"OR": "a"},
+			}
+			for _, filter := range invalid {
+				if _, err := ParseUserWhere(filter); !errors.Is(err, ErrInvalidFilter) {
+					t.Fatalf("expected ErrInvalidFilter for %v, got %v", filter, err)
+				}
+			}
+
+			if _, err := UserOrderByFromString("-unknown"); !errors.Is(err, ErrInvalidFilter) {
+				t.Fatalf("expected ErrInvalidFilter, got %v", err)
+			}
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
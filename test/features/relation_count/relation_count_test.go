@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+// language=GraphQL
+var posts = []string{`
+	mutation {
+		result: createOnePost(data: {
+			id: "a",
+			title: "first",
+			count: 5,
+			comments: {
+				create: [
+					{ id: "c1", content: "hello" },
+					{ id: "c2", content: "hello world" },
+					{ id: "c3", content: "bye" },
+				],
+			},
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOnePost(data: {
+			id: "b",
+			title: "second",
+		}) {
+			id
+		}
+	}
+`}
+
+func TestRelationCount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "find many",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.Post.FindMany().WithCount(
+				Post.Comments.Count(),
+			).OrderBy(
+				Post.ID.Order(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := []PostModel{{
+				InnerPost: InnerPost{
+					ID:    "a",
+					Title: "first",
+					Count: 5,
+				},
+				RelationsPost: RelationsPost{
+					RelationCount: &PostCount{
+						Comments: 3,
+					},
+				},
+			}, {
+				InnerPost: InnerPost{
+					ID:    "b",
+					Title: "second",
+				},
+				RelationsPost: RelationsPost{
+					RelationCount: &PostCount{
+						Comments: 0,
+					},
+				},
+			}}
+
+			massert.Equal(t, expected, actual)
+		},
+	}, {
+		name:   "find unique with filter",
+		before: posts,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.Post.FindUnique(
+				Post.ID.Equals("a"),
+			).WithCount(
+				Post.Comments.Count(
+					Comment.Content.Contains("hello"),
+				),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 5, actual.Count)
+			massert.Equal(t, &PostCount{Comments: 2}, actual.RelationCount)
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+func TestSoftDelete(t *testing.T) {
+	t.Parallel()
+
+	before := []string{`
+		mutation {
+			result: createOneUser(data: {
+				id: "a",
+				name: "a",
+				posts: {
+					create: [{
+						id: "a1",
+						title: "a1",
+					}, {
+						id: "a2",
+						title: "a2",
+						deletedAt: "2020-01-01T00:00:00Z",
+					}],
+				},
+			}) {
+				id
+			}
+		}
+	`, `
+		mutation {
+			result: createOneUser(data: {
+				id: "b",
+				name: "b",
+				deletedAt: "2020-01-01T00:00:00Z",
+			}) {
+				id
+			}
+		}
+	`}
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "find excludes deleted records",
+		before: before,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			users, err := client.User.FindMany().OrderBy(User.ID.Order(SortOrderAsc)).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, len(users))
+			massert.Equal(t, "a", users[0].ID)
+
+			_, err = client.User.FindUnique(User.ID.Equals("b")).Exec(ctx)
+			if !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+
+			count, err := client.User.Count().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, count)
+		},
+	}, {
+		name:   "with deleted",
+		before: before,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			users, err := client.User.FindMany().WithDeleted().OrderBy(User.ID.Order(SortOrderAsc)).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 2, len(users))
+
+			user, err := client.User.FindUnique(User.ID.Equals("b")).WithDeleted().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, "b", user.ID)
+
+			count, err := client.User.Count().WithDeleted().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 2, count)
+
+			deleted, err := client.User.FindMany(User.DeletedAt.Lte(time.Now())).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, len(deleted))
+			massert.Equal(t, "b", deleted[0].ID)
+		},
+	}, {
+		name:   "fetch relations",
+		before: before,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			user, err := client.User.FindUnique(User.ID.Equals("a")).With(
+				User.Posts.Fetch(),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, len(user.Posts()))
+			massert.Equal(t, "a1", user.Posts()[0].ID)
+
+			user, err = client.User.FindUnique(User.ID.Equals("a")).With(
+				User.Posts.Fetch().WithDeleted().OrderBy(Post.ID.Order(SortOrderAsc)),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 2, len(user.Posts()))
+		},
+	}, {
+		name:   "delete",
+		before: before,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			deleted, err := client.User.FindUnique(User.ID.Equals("a")).Delete().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := deleted.DeletedAt(); !ok {
+				t.Fatal("expected deletedAt to be set")
+			}
+
+			_, err = client.User.FindUnique(User.ID.Equals("a")).Delete().Exec(ctx)
+			if !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+
+			result, err := client.Post.FindMany().Delete().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, result.Count)
+
+			count, err := client.Post.Count().WithDeleted().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 2, count)
+		},
+	}, {
+		name:   "delete sets the time when executed",
+		before: before,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			query := client.User.FindUnique(User.ID.Equals("a")).Delete()
+
+			time.Sleep(time.Second)
+			executed := time.Now()
+
+			deleted, err := query.Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			deletedAt, ok := deleted.DeletedAt()
+			if !ok {
+				t.Fatal("expected deletedAt to be set")
+			}
+			if deletedAt.Before(executed.Truncate(time.Millisecond)) {
+				t.Fatalf("expected deletedAt %s to be after %s", deletedAt, executed)
+			}
+		},
+	}, {
+		name:   "aggregate and group by exclude deleted records",
+		before: before,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			aggregate, err := client.Post.Aggregate(Post.Title.Count()).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, aggregate.Count.All)
+
+			aggregate, err = client.Post.Aggregate(Post.Title.Count()).WithDeleted().Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 2, aggregate.Count.All)
+
+			groups, err := client.User.GroupBy(User.Name.Field()).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, len(groups))
+			massert.Equal(t, "a", groups[0].Name)
+
+			groups, err = client.User.GroupBy(User.Name.Field()).WithDeleted().OrderBy(User.Name.Order(SortOrderAsc)).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 2, len(groups))
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
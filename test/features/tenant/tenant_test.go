@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+type tenantKey struct{}
+
+func tenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	return tenant, ok
+}
+
+func TestTenant(t *testing.T) {
+	t.Parallel()
+
+	before := []string{`
+		mutation {
+			result: createOneUser(data: {
+				id: "a",
+				name: "a",
+				tenantId: "a",
+				posts: {
+					create: [{
+						id: "a1",
+						title: "a1",
+						tenantId: "a",
+					}, {
+						id: "a2",
+						title: "a2",
+						tenantId: "b",
+					}],
+				},
+			}) {
+				id
+			}
+		}
+	`, `
+		mutation {
+			result: createOneUser(data: {
+				id: "b",
+				name: "b",
+				tenantId: "b",
+			}) {
+				id
+			}
+		}
+	`}
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "find",
+		before: before,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			ctx = context.WithValue(ctx, tenantKey{}, "a")
+
+			users, err := client.User.FindMany().With(
+				User.Posts.Fetch(),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 1, len(users))
+			massert.Equal(t, "a", users[0].ID)
+			massert.Equal(t, 1, len(users[0].Posts()))
+			massert.Equal(t, "a1", users[0].Posts()[0].ID)
+
+			_, err = client.User.FindUnique(User.ID.Equals("b")).Exec(ctx)
+			if !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+
+			count, err := client.User.Count(
+				User.Posts.Some(Post.Title.Equals("a2")),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 0, count)
+		},
+	}, {
+		name:   "write",
+		before: before,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			ctx = context.WithValue(ctx, tenantKey{}, "b")
+
+			user, err := client.User.CreateOne(
+				User.Name.Set("c"),
+				User.TenantID.Set("b"),
+				User.ID.Set("c"),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, "b", user.TenantID)
+
+			_, err = client.User.CreateOne(
+				User.Name.Set("d"),
+				User.TenantID.Set("a"),
+			).Exec(ctx)
+			if !errors.Is(err, ErrTenantMismatch) {
+				t.Fatalf("expected ErrTenantMismatch, got %v", err)
+			}
+
+			result, err := client.User.FindMany().Update(User.Name.Set("updated")).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			massert.Equal(t, 2, result.Count)
+
+			_, err = client.User.FindUnique(User.ID.Equals("a")).Delete().Exec(ctx)
+			if !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+		},
+	}, {
+		name:   "fail closed",
+		before: before,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			_, err := client.User.FindMany().Exec(ctx)
+			if !errors.Is(err, ErrNoTenant) {
+				t.Fatalf("expected ErrNoTenant, got %v", err)
+			}
+
+			// post a2 of tenant b belongs to user a of tenant a, which must not be returned
+			_, err = client.Post.FindUnique(Post.ID.Equals("a2")).With(
+				Post.Author.Fetch(),
+			).Exec(context.WithValue(ctx, tenantKey{}, "b"))
+			if !errors.Is(err, ErrUnscopedRelation) {
+				t.Fatalf("expected ErrUnscopedRelation, got %v", err)
+			}
+
+			var res []struct{}
+			err = client.Prisma.QueryRaw(`SELECT 1`).Exec(context.WithValue(ctx, tenantKey{}, "a"), &res)
+			if !errors.Is(err, ErrRawQueryNotAllowed) {
+				t.Fatalf("expected ErrRawQueryNotAllowed, got %v", err)
+			}
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient(WithTenantScope("tenantId", tenantFromContext))
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -105,6 +106,48 @@ func TestTransaction(t *testing.T) {
 
 			massert.Equal(t, expected, actual)
 		},
+	}, {
+		name: "tx error",
+		// language=GraphQL
+		before: []string{`
+			mutation {
+				result: createOneUser(data: {
+					id: "exists",
+					email: "email",
+				}) {
+					id
+				}
+			}
+		`},
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			a := client.User.CreateOne(
+				User.Email.Set("a"),
+				User.ID.Set("a"),
+			).Tx()
+
+			// this violates the unique constraint of the id
+			b := client.User.CreateOne(
+				User.Email.Set("b"),
+				User.ID.Set("exists"),
+			).Tx()
+
+			err := client.Prisma.Transaction(a, b).Exec(ctx)
+
+			var txErr *TxError
+			if !errors.As(err, &txErr) {
+				t.Fatalf("expected a TxError, got %v", err)
+			}
+			assert.Equal(t, 1, txErr.Index)
+			assert.Equal(t, "User", txErr.Model)
+			assert.Equal(t, "createOne", txErr.Method)
+			assert.Equal(t, "P2002", txErr.UserFacingError.ErrorCode)
+
+			_, ok := IsErrUniqueConstraint(err)
+			assert.True(t, ok)
+
+			_, err = a.ResultErr()
+			assert.ErrorIs(t, err, ErrNoResult)
+		},
 	}}
 	for _, tt := range tests {
 		tt := tt
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/steebchen/prisma-client-go/test"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+func TestTypedErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name: "unique constraint fields",
+		// language=GraphQL
+		before: []string{`
+			mutation {
+				result: createOneUser(data: {
+					id: "a",
+					email: "a",
+				}) {
+					id
+				}
+			}
+		`},
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			_, err := client.User.CreateOne(
+				User.Email.Set("a"),
+			).Exec(ctx)
+
+			fields, ok := User.ConstraintFields(err)
+			assert.True(t, ok)
+			assert.Equal(t, []userPrismaFields{User.Email.Field()}, fields)
+
+			_, ok = Post.ConstraintFields(err)
+			assert.False(t, ok)
+		},
+	}, {
+		name: "foreign key constraint",
+		// language=GraphQL
+		before: []string{`
+			mutation {
+				result: createOneUser(data: {
+					id: "a",
+					email: "a",
+					posts: {
+						create: [{ id: "p", title: "p" }],
+					},
+				}) {
+					id
+				}
+			}
+		`},
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			// the user can not be deleted while it has posts
+			_, err := client.User.FindUnique(
+				User.ID.Equals("a"),
+			).Delete().Exec(ctx)
+
+			var fk *ErrForeignKeyConstraint
+			if !errors.As(err, &fk) {
+				t.Fatalf("expected ErrForeignKeyConstraint, got %v", err)
+			}
+			assert.Equal(t, "P2003", fk.ErrorCode)
+
+			fields, ok := Post.ConstraintFields(err)
+			assert.True(t, ok)
+			assert.Equal(t, []postPrismaFields{Post.AuthorID.Field()}, fields)
+		},
+	}, {
+		name: "record not found",
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			_, err := client.Post.CreateOne(
+				Post.Title.Set("p"),
+				Post.Author.Link(
+					User.ID.Equals("does-not-exist"),
+				),
+			).Exec(ctx)
+
+			var notFound *ErrRecordNotFound
+			if !errors.As(err, &notFound) {
+				t.Fatalf("expected ErrRecordNotFound, got %v", err)
+			}
+			assert.True(t, IsErrNotFound(err))
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, test.Databases, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
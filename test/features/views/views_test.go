@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/test"
+	"github.com/steebchen/prisma-client-go/test/helpers/massert"
+)
+
+type cx = context.Context
+type Func func(t *testing.T, client *PrismaClient, ctx cx)
+
+// language=GraphQL
+var data = []string{`
+	mutation {
+		result: createOneUser(data: {
+			id: "a",
+			name: "Alice",
+			posts: {
+				create: [{ id: "p1", title: "1" }, { id: "p2", title: "2" }],
+			},
+		}) {
+			id
+		}
+	}
+`, `
+	mutation {
+		result: createOneUser(data: {
+			id: "b",
+			name: "Bob",
+			posts: {
+				create: [{ id: "p3", title: "3" }],
+			},
+		}) {
+			id
+		}
+	}
+`}
+
+// views are not created by `db push`, so they are created manually
+const createView = `
+	CREATE VIEW "UserStats" AS
+	SELECT u.id AS "userId", u.name, COUNT(p.id)::int AS "postCount"
+	FROM "User" u LEFT JOIN "Post" p ON p."authorId" = u.id
+	GROUP BY u.id, u.name
+`
+
+func TestViews(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		before []string
+		run    Func
+	}{{
+		name:   "find many",
+		before: data,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.UserStats.FindMany().OrderBy(
+				UserStats.UserID.Order(SortOrderAsc),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, []UserStatsModel{{
+				InnerUserStats: InnerUserStats{
+					UserID:    "a",
+					Name:      "Alice",
+					PostCount: 2,
+				},
+			}, {
+				InnerUserStats: InnerUserStats{
+					UserID:    "b",
+					Name:      "Bob",
+					PostCount: 1,
+				},
+			}}, actual)
+		},
+	}, {
+		name:   "find unique",
+		before: data,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			actual, err := client.UserStats.FindUnique(
+				UserStats.UserID.Equals("b"),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 1, actual.PostCount)
+		},
+	}, {
+		name:   "filter and count",
+		before: data,
+		run: func(t *testing.T, client *PrismaClient, ctx cx) {
+			count, err := client.UserStats.Count(
+				UserStats.PostCount.Gt(1),
+			).Exec(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			massert.Equal(t, 1, count)
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.RunSerial(t, []test.Database{test.PostgreSQL}, func(t *testing.T, db test.Database, ctx context.Context) {
+				client := NewClient()
+				mockDBName := test.Start(t, db, client.Engine, tt.before)
+				defer test.End(t, db, client.Engine, mockDBName)
+
+				if _, err := client.Prisma.ExecuteRaw(createView).Exec(ctx); err != nil {
+					t.Fatal(err)
+				}
+
+				tt.run(t, client, context.Background())
+			})
+		})
+	}
+}
+
+// TestViewsAreReadOnly makes sure that no write methods are generated for views
+func TestViewsAreReadOnly(t *testing.T) {
+	client := NewClient()
+
+	checks := []struct {
+		v       interface{}
+		methods []string
+	}{{
+		v:       client.UserStats,
+		methods: []string{"CreateOne", "CreateMany", "CreateManyAndReturn", "UpsertOne"},
+	}, {
+		v:       client.UserStats.FindUnique(UserStats.UserID.Equals("a")),
+		methods: []string{"Update", "Delete"},
+	}, {
+		v:       client.UserStats.FindMany(),
+		methods: []string{"Update", "Delete"},
+	}, {
+		v:       UserStats.Name,
+		methods: []string{"Set", "SetIfPresent"},
+	}}
+	for _, check := range checks {
+		for _, method := range check.methods {
+			if _, ok := reflect.TypeOf(check.v).MethodByName(method); ok {
+				t.Errorf("%T must not have a %s method", check.v, method)
+			}
+		}
+	}
+
+	// models still have write methods
+	if _, ok := reflect.TypeOf(client.User).MethodByName("CreateOne"); !ok {
+		t.Errorf("%T must have a CreateOne method", client.User)
+	}
+}